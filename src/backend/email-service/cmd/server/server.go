@@ -4,7 +4,10 @@ package main
 
 import (
     "context"
+    "database/sql"
+    "flag"
     "fmt"
+    "log/slog"
     "net"
     "net/http"
     "os"
@@ -14,42 +17,53 @@ import (
     "time"
 
     "github.com/gin-gonic/gin" // v1.9.1
+    _ "github.com/lib/pq" // v1.10.9
     "github.com/prometheus/client_golang/prometheus" // v1.17.0
     "github.com/prometheus/client_golang/prometheus/promhttp"
     "github.com/sony/gobreaker" // v1.5.0
-    "go.uber.org/zap" // v1.26.0
     "golang.org/x/time/rate" // v0.3.0
     "google.golang.org/grpc" // v1.58.2
     "google.golang.org/grpc/health"
     "google.golang.org/grpc/health/grpc_health_v1"
     "google.golang.org/grpc/keepalive"
 
+    "github.com/email-management-platform/backend/email-service/internal/breaker"
+    "github.com/email-management-platform/backend/email-service/internal/cache"
     "github.com/email-management-platform/backend/email-service/internal/config"
     "github.com/email-management-platform/backend/email-service/internal/handlers"
+    "github.com/email-management-platform/backend/email-service/internal/logging"
+    "github.com/email-management-platform/backend/email-service/internal/repositories"
     "github.com/email-management-platform/backend/email-service/internal/services"
 )
 
 const (
     defaultGracePeriod    = time.Second * 30
     defaultMetricsPath    = "/metrics"
+    defaultDBMetricsPath  = "/db_metrics"
     defaultHealthCheckPath = "/health"
     defaultShutdownTimeout = time.Second * 60
     defaultRequestTimeout  = time.Second * 30
+    defaultDBMetricsPortOffset = 3
 )
 
 // Server represents the main server instance with enhanced reliability features
 type Server struct {
-    cfg            *config.Config
-    httpServer     *http.Server
-    grpcServer     *grpc.Server
-    metricsServer  *http.Server
-    healthCheck    *health.Server
-    logger         *zap.Logger
-    emailService   services.EmailService
-    rateLimiter    *rate.Limiter
-    circuitBreaker *gobreaker.CircuitBreaker
-    shutdownTimeout time.Duration
-    wg             sync.WaitGroup
+    cfg              *config.Config
+    httpServer       *http.Server
+    grpcServer       *grpc.Server
+    metricsServer    *http.Server
+    dbMetricsServer  *http.Server
+    datastoreCollector *repositories.DatastoreCollector
+    healthCheck      *health.Server
+    logger           *slog.Logger
+    db               *sql.DB
+    bodyCache        *cache.Store
+    emailService     *services.EmailService
+    emailHandler     *handlers.EmailHandler
+    rateLimiter      *rate.Limiter
+    circuitBreaker   *gobreaker.CircuitBreaker
+    shutdownTimeout  time.Duration
+    wg               sync.WaitGroup
 }
 
 // Metrics collectors
@@ -78,16 +92,15 @@ func init() {
     prometheus.MustRegister(requestLatency)
 }
 
-// newServer creates a new server instance with enhanced reliability features
-func newServer(cfg *config.Config) (*Server, error) {
+// NewServer creates a new server instance with enhanced reliability
+// features. logger may be nil, in which case slog.Default() is used.
+func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
     if err := cfg.Validate(); err != nil {
         return nil, fmt.Errorf("invalid configuration: %w", err)
     }
 
-    // Initialize logger
-    logger, err := zap.NewProduction()
-    if err != nil {
-        return nil, fmt.Errorf("failed to initialize logger: %w", err)
+    if logger == nil {
+        logger = slog.Default()
     }
 
     // Initialize rate limiter
@@ -104,17 +117,86 @@ func newServer(cfg *config.Config) (*Server, error) {
         },
     })
 
-    // Initialize email service
-    emailService, err := services.NewEmailService(nil) // Repository would be initialized here
+    // Initialize email service. The repository shares the first configured
+    // shard's connection the same way internal/cli's commands do; per-shard
+    // routing itself happens inside the repository via cfg.Shards.
+    if len(cfg.Shards.Shards) == 0 {
+        return nil, fmt.Errorf("failed to initialize email service: no database shard is configured")
+    }
+    db, err := sql.Open("postgres", cfg.Shards.Shards[0].DataSourceName)
+    if err != nil {
+        return nil, fmt.Errorf("failed to open database connection: %w", err)
+    }
+
+    repo, err := repositories.NewEmailRepository(db, &cfg.Shards, logger)
+    if err != nil {
+        db.Close()
+        return nil, fmt.Errorf("failed to initialize email repository: %w", err)
+    }
+
+    serviceOpts := []services.Option{}
+    var bodyCache *cache.Store
+    if cfg.BodyCache.BaseDir != "" {
+        bodyCache, err = cache.NewStore(cache.Config{
+            BaseDir:      cfg.BodyCache.BaseDir,
+            MaxBytes:     cfg.BodyCache.MaxBytes,
+            MinFreeBytes: cfg.BodyCache.MinFreeBytes,
+        }, logger)
+        if err != nil {
+            db.Close()
+            return nil, fmt.Errorf("failed to initialize body cache: %w", err)
+        }
+        bodyCache.Start()
+        serviceOpts = append(serviceOpts, services.WithBodyCache(bodyCache))
+    }
+    if cfg.Mail.Transport == "smtp" && cfg.Mail.SMTP.Host != "" {
+        courier := services.NewSMTPCourier(services.SMTPCourierConfig{
+            Host:     cfg.Mail.SMTP.Host,
+            Port:     cfg.Mail.SMTP.Port,
+            Username: cfg.Mail.SMTP.Username,
+            Password: cfg.Mail.SMTP.Password,
+        })
+        serviceOpts = append(serviceOpts, services.WithCourier(courier))
+
+        // A positive cfg.Mail.WorkerCount (LoadConfig defaults it to
+        // config.DefaultMailWorkerCount) selects asynchronous,
+        // per-destination-domain rate-limited delivery instead of
+        // dispatching inline; 0 keeps the original synchronous behavior.
+        if cfg.Mail.WorkerCount > 0 {
+            deliveryQueue := services.NewDeliveryQueue(courier, services.DeliveryQueueOptions{
+                Workers:        cfg.Mail.WorkerCount,
+                QueueSize:      cfg.Mail.BatchSize,
+                RatePerDomain:  cfg.Mail.RatePerSecond,
+                BurstPerDomain: cfg.Mail.RateBurst,
+            })
+            deliveryQueue.Start()
+            serviceOpts = append(serviceOpts, services.WithDeliveryQueue(deliveryQueue))
+        }
+    }
+    if cfg.Mail.ReplyDomain != "" && cfg.Mail.ReplySecret != "" {
+        serviceOpts = append(serviceOpts, services.WithReplyAddressing(cfg.Mail.ReplyDomain, []byte(cfg.Mail.ReplySecret)))
+    }
+
+    emailService, err := services.NewEmailService(repo, serviceOpts...)
     if err != nil {
+        db.Close()
         return nil, fmt.Errorf("failed to initialize email service: %w", err)
     }
 
+    emailHandler, err := handlers.NewEmailHandler(emailService, cfg.RateLimit, breaker.NewRegistry(cfg.Breakers))
+    if err != nil {
+        db.Close()
+        return nil, fmt.Errorf("failed to initialize email handler: %w", err)
+    }
+
     // Initialize HTTP router
     router := gin.New()
     router.Use(gin.Recovery())
-    
-    // Initialize gRPC server with keepalive settings
+
+    // Initialize gRPC server with keepalive settings. emailHandler's
+    // interceptors are chained in here, at construction time, since
+    // grpc.Server only accepts interceptors this way (see
+    // EmailHandler.RegisterGRPCServer).
     grpcServer := grpc.NewServer(
         grpc.KeepaliveParams(keepalive.ServerParameters{
             MaxConnectionIdle:     time.Minute * 5,
@@ -123,21 +205,43 @@ func newServer(cfg *config.Config) (*Server, error) {
             Time:                  time.Minute,
             Timeout:              time.Second * 20,
         }),
+        grpc.ChainUnaryInterceptor(emailHandler.UnaryServerInterceptors()...),
     )
 
     // Initialize health check server
     healthCheck := health.NewServer()
     grpc_health_v1.RegisterHealthServer(grpcServer, healthCheck)
 
+    // Initialize the datastore collector on its own registry, so a slow or
+    // down shard scrape can only stall /db_metrics, never the hot-path
+    // /metrics endpoint above.
+    dbRegistry := prometheus.NewRegistry()
+    datastoreCollector, err := repositories.NewDatastoreCollector(cfg.Shards.Shards, cfg.Metrics.DBScrapeTimeout, logger)
+    if err != nil {
+        return nil, fmt.Errorf("failed to initialize datastore collector: %w", err)
+    }
+    dbRegistry.MustRegister(datastoreCollector)
+
+    dbMetricsPort := cfg.Metrics.DBMetricsPort
+    if dbMetricsPort == 0 {
+        dbMetricsPort = cfg.Port + defaultDBMetricsPortOffset
+    }
+    dbMetricsMux := http.NewServeMux()
+    dbMetricsMux.Handle(defaultDBMetricsPath, promhttp.HandlerFor(dbRegistry, promhttp.HandlerOpts{}))
+
     // Create server instance
     server := &Server{
-        cfg:            cfg,
-        logger:         logger,
-        emailService:   emailService,
-        rateLimiter:    limiter,
-        circuitBreaker: cb,
-        healthCheck:    healthCheck,
-        shutdownTimeout: defaultShutdownTimeout,
+        cfg:                cfg,
+        logger:             logger,
+        db:                 db,
+        bodyCache:          bodyCache,
+        emailService:       emailService,
+        emailHandler:       emailHandler,
+        rateLimiter:        limiter,
+        circuitBreaker:     cb,
+        healthCheck:        healthCheck,
+        datastoreCollector: datastoreCollector,
+        shutdownTimeout:    defaultShutdownTimeout,
         httpServer: &http.Server{
             Handler:      router,
             ReadTimeout:  defaultRequestTimeout,
@@ -147,14 +251,47 @@ func newServer(cfg *config.Config) (*Server, error) {
         metricsServer: &http.Server{
             Handler: promhttp.Handler(),
         },
+        dbMetricsServer: &http.Server{
+            Addr:    fmt.Sprintf(":%d", dbMetricsPort),
+            Handler: dbMetricsMux,
+        },
     }
 
-    // Register HTTP routes
+    // Register HTTP and gRPC routes
     server.registerHTTPRoutes(router)
+    emailHandler.RegisterGRPCServer(grpcServer)
 
     return server, nil
 }
 
+// registerHTTPRoutes mounts the email handler's routes under the service's
+// versioned API prefix.
+func (s *Server) registerHTTPRoutes(router *gin.Engine) {
+    group := router.Group("/api/v1")
+    s.emailHandler.RegisterHTTPRoutes(group)
+}
+
+// EmailService returns the server's email service, used by subsystems
+// started alongside the server (e.g. gmailwatch) that need to route
+// incoming mailbox changes into the same processing pipeline.
+func (s *Server) EmailService() *services.EmailService {
+    return s.emailService
+}
+
+// EmailHandler returns the server's email handler, used by subsystems
+// started alongside the server (e.g. gmailwatch) that need to reuse the
+// same handler instance rather than constructing (and registering on the
+// gRPC server) a second one.
+func (s *Server) EmailHandler() *handlers.EmailHandler {
+    return s.emailHandler
+}
+
+// GRPCServer returns the server's gRPC server, for callers that need to
+// register additional services on it before Start.
+func (s *Server) GRPCServer() *grpc.Server {
+    return s.grpcServer
+}
+
 // Start initializes and starts all servers with enhanced monitoring
 func (s *Server) Start() error {
     // Start uptime tracking
@@ -171,10 +308,10 @@ func (s *Server) Start() error {
     go func() {
         defer s.wg.Done()
         addr := fmt.Sprintf(":%d", s.cfg.Port)
-        s.logger.Info("starting HTTP server", zap.String("addr", addr))
+        s.logger.Info("starting HTTP server", "addr", addr)
         activeConnections.WithLabelValues("http").Inc()
         if err := s.httpServer.ListenAndServe(); err != http.ErrServerClosed {
-            s.logger.Error("HTTP server error", zap.Error(err))
+            s.logger.Error("HTTP server error", "error", err)
         }
         activeConnections.WithLabelValues("http").Dec()
     }()
@@ -186,13 +323,13 @@ func (s *Server) Start() error {
         addr := fmt.Sprintf(":%d", s.cfg.Port+1)
         lis, err := net.Listen("tcp", addr)
         if err != nil {
-            s.logger.Error("failed to start gRPC listener", zap.Error(err))
+            s.logger.Error("failed to start gRPC listener", "error", err)
             return
         }
-        s.logger.Info("starting gRPC server", zap.String("addr", addr))
+        s.logger.Info("starting gRPC server", "addr", addr)
         activeConnections.WithLabelValues("grpc").Inc()
         if err := s.grpcServer.Serve(lis); err != nil {
-            s.logger.Error("gRPC server error", zap.Error(err))
+            s.logger.Error("gRPC server error", "error", err)
         }
         activeConnections.WithLabelValues("grpc").Dec()
     }()
@@ -202,9 +339,20 @@ func (s *Server) Start() error {
     go func() {
         defer s.wg.Done()
         addr := fmt.Sprintf(":%d", s.cfg.Port+2)
-        s.logger.Info("starting metrics server", zap.String("addr", addr))
+        s.logger.Info("starting metrics server", "addr", addr)
         if err := s.metricsServer.ListenAndServe(); err != http.ErrServerClosed {
-            s.logger.Error("metrics server error", zap.Error(err))
+            s.logger.Error("metrics server error", "error", err)
+        }
+    }()
+
+    // Start the db_metrics server, isolated from the primary metrics server
+    // so an expensive/stalled shard scrape can't block it.
+    s.wg.Add(1)
+    go func() {
+        defer s.wg.Done()
+        s.logger.Info("starting db_metrics server", "addr", s.dbMetricsServer.Addr)
+        if err := s.dbMetricsServer.ListenAndServe(); err != http.ErrServerClosed {
+            s.logger.Error("db_metrics server error", "error", err)
         }
     }()
 
@@ -224,7 +372,7 @@ func (s *Server) Shutdown(ctx context.Context) error {
 
     // Shutdown HTTP server
     if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
-        s.logger.Error("HTTP server shutdown error", zap.Error(err))
+        s.logger.Error("HTTP server shutdown error", "error", err)
     }
 
     // Shutdown gRPC server
@@ -232,7 +380,24 @@ func (s *Server) Shutdown(ctx context.Context) error {
 
     // Shutdown metrics server
     if err := s.metricsServer.Shutdown(shutdownCtx); err != nil {
-        s.logger.Error("metrics server shutdown error", zap.Error(err))
+        s.logger.Error("metrics server shutdown error", "error", err)
+    }
+
+    // Shutdown db_metrics server
+    if err := s.dbMetricsServer.Shutdown(shutdownCtx); err != nil {
+        s.logger.Error("db_metrics server shutdown error", "error", err)
+    }
+    if err := s.datastoreCollector.Close(); err != nil {
+        s.logger.Error("failed to close datastore collector", "error", err)
+    }
+    if err := s.emailService.Close(); err != nil {
+        s.logger.Error("failed to close email service", "error", err)
+    }
+    if err := s.db.Close(); err != nil {
+        s.logger.Error("failed to close database connection", "error", err)
+    }
+    if s.bodyCache != nil {
+        s.bodyCache.Stop()
     }
 
     // Wait for all goroutines to finish
@@ -249,29 +414,46 @@ func (s *Server) Shutdown(ctx context.Context) error {
         s.logger.Warn("shutdown deadline exceeded")
     }
 
-    // Flush logger
-    return s.logger.Sync()
+    // Flush any suppressed duplicate error records
+    return logging.Flush(ctx, s.logger)
 }
 
+// Command-line flags controlling log output, mirroring the Prometheus
+// flag-surface convention used elsewhere in this service.
+var (
+    logFormat = flag.String("log.format", "json", "log output format: json|logfmt")
+    logLevel  = flag.String("log.level", "info", "log level: debug|info|warn|error")
+)
+
 func main() {
-    // Initialize logger
-    logger, _ := zap.NewProduction()
-    defer logger.Sync()
+    flag.Parse()
+
+    // Initialize structured logger
+    logger, err := logging.New(logging.Options{
+        Format: logging.Format(*logFormat),
+        Level:  *logLevel,
+    })
+    if err != nil {
+        panic("failed to initialize logger: " + err.Error())
+    }
 
     // Load configuration
     cfg, err := config.LoadConfig(".", os.Getenv("ENV"))
     if err != nil {
-        logger.Fatal("failed to load configuration", zap.Error(err))
+        logger.Error("failed to load configuration", "error", err)
+        os.Exit(1)
     }
 
     // Create and start server
-    server, err := newServer(cfg)
+    srv, err := NewServer(cfg, logger)
     if err != nil {
-        logger.Fatal("failed to create server", zap.Error(err))
+        logger.Error("failed to create server", "error", err)
+        os.Exit(1)
     }
 
-    if err := server.Start(); err != nil {
-        logger.Fatal("failed to start server", zap.Error(err))
+    if err := srv.Start(); err != nil {
+        logger.Error("failed to start server", "error", err)
+        os.Exit(1)
     }
 
     // Handle shutdown signals
@@ -283,8 +465,8 @@ func main() {
     shutdownCtx, cancel := context.WithTimeout(context.Background(), defaultGracePeriod)
     defer cancel()
 
-    if err := server.Shutdown(shutdownCtx); err != nil {
-        logger.Error("shutdown error", zap.Error(err))
+    if err := srv.Shutdown(shutdownCtx); err != nil {
+        logger.Error("shutdown error", "error", err)
         os.Exit(1)
     }
 }
\ No newline at end of file
@@ -0,0 +1,164 @@
+// Package breaker provides a registry of per-(provider, operation) circuit
+// breakers, so an outage in one downstream (Gmail, say) can't trip traffic
+// to an unrelated one (Outlook, the database) that happens to share a
+// process. It also implements a TCP-slow-start-style adaptive throttle:
+// once a breaker recovers from an open trip, callers are told to use a
+// reduced page size/concurrency limit for a configurable number of
+// successful calls before the configured value is restored.
+package breaker
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus" // v1.17.0
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sony/gobreaker" // v0.5.0
+
+	"github.com/email-management-platform/backend/email-service/internal/config"
+)
+
+// breakerState exposes a breaker's current gobreaker.State as a gauge,
+// labeled by provider and operation, matching gobreaker's own
+// Closed=0/HalfOpen=1/Open=2 ordering.
+var breakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "email_breaker_state",
+	Help: "Circuit breaker state per (provider, operation): 0=closed, 1=half-open, 2=open",
+}, []string{"provider", "op"})
+
+// Key identifies a single breaker by the downstream it guards and the
+// operation being performed against it.
+type Key struct {
+	Provider  string
+	Operation string
+}
+
+// entry pairs a breaker with the slow-start bookkeeping for its key.
+type entry struct {
+	cb  *gobreaker.CircuitBreaker
+	cfg config.ProviderBreakerConfig
+
+	mu                sync.Mutex
+	slowStartActive   bool
+	slowStartRemaining int
+}
+
+// Registry lazily creates and caches one circuit breaker per Key, built
+// from the provider's configured thresholds.
+type Registry struct {
+	mu       sync.Mutex
+	cfg      config.BreakerConfig
+	entries  map[Key]*entry
+}
+
+// NewRegistry creates a Registry using cfg's per-provider thresholds.
+func NewRegistry(cfg config.BreakerConfig) *Registry {
+	return &Registry{
+		cfg:     cfg,
+		entries: make(map[Key]*entry),
+	}
+}
+
+// Execute runs fn through the breaker for key, creating it on first use.
+// On success, if the breaker is in its post-recovery slow-start window,
+// the window's remaining count is decremented.
+func (r *Registry) Execute(key Key, fn func() (interface{}, error)) (interface{}, error) {
+	e := r.entryFor(key)
+
+	result, err := e.cb.Execute(fn)
+	if err == nil {
+		e.recordSuccess()
+	}
+	return result, err
+}
+
+// PageSize returns the page size/concurrency limit callers should use for
+// key's next request: configured, unless key's breaker is still within its
+// post-recovery slow-start window, in which case the provider's configured
+// SlowStartPageSize is returned instead.
+func (r *Registry) PageSize(key Key, configured int) int {
+	e := r.entryFor(key)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.slowStartActive && e.cfg.SlowStartPageSize > 0 {
+		return e.cfg.SlowStartPageSize
+	}
+	return configured
+}
+
+// State returns key's current breaker state, creating it on first use.
+func (r *Registry) State(key Key) gobreaker.State {
+	return r.entryFor(key).cb.State()
+}
+
+func (e *entry) recordSuccess() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.slowStartActive {
+		return
+	}
+	e.slowStartRemaining--
+	if e.slowStartRemaining <= 0 {
+		e.slowStartActive = false
+	}
+}
+
+func (e *entry) startSlowStart() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cfg.SlowStartRequests > 0 {
+		e.slowStartActive = true
+		e.slowStartRemaining = e.cfg.SlowStartRequests
+	}
+}
+
+func (r *Registry) entryFor(key Key) *entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if e, ok := r.entries[key]; ok {
+		return e
+	}
+
+	providerCfg := r.providerConfig(key.Provider)
+	e := &entry{cfg: providerCfg}
+	e.cb = newBreaker(key, providerCfg, e)
+	r.entries[key] = e
+	return e
+}
+
+func (r *Registry) providerConfig(provider string) config.ProviderBreakerConfig {
+	switch provider {
+	case "gmail":
+		return r.cfg.Gmail
+	case "outlook":
+		return r.cfg.Outlook
+	case "database":
+		return r.cfg.Database
+	default:
+		// Keys outside the three configured downstreams (e.g. a handler
+		// guarding its own route, not a specific provider) still get a
+		// usable breaker rather than one that trips on the first request.
+		return config.DefaultProviderBreakerConfig()
+	}
+}
+
+// newBreaker builds the gobreaker.CircuitBreaker for key/cfg. e is the
+// owning entry, wired into OnStateChange so a recovery to Closed kicks off
+// the slow-start window and every transition updates the state gauge.
+func newBreaker(key Key, cfg config.ProviderBreakerConfig, e *entry) *gobreaker.CircuitBreaker {
+	return gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        key.Provider + "-" + key.Operation,
+		MaxRequests: cfg.MaxRequests,
+		Timeout:     cfg.Timeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= cfg.ConsecutiveFailures
+		},
+		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
+			breakerState.WithLabelValues(key.Provider, key.Operation).Set(float64(to))
+			if to == gobreaker.StateClosed && from != gobreaker.StateClosed {
+				e.startSlowStart()
+			}
+		},
+	})
+}
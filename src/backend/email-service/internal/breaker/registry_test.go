@@ -0,0 +1,99 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sony/gobreaker" // v0.5.0
+
+	"github.com/email-management-platform/backend/email-service/internal/config"
+)
+
+func testConfig() config.BreakerConfig {
+	cfg := config.ProviderBreakerConfig{
+		MaxRequests:         1,
+		Timeout:             config.DefaultBreakerTimeout,
+		ConsecutiveFailures: 3,
+		SlowStartRequests:   2,
+		SlowStartPageSize:   5,
+	}
+	return config.BreakerConfig{Gmail: cfg, Outlook: cfg, Database: cfg}
+}
+
+// TestGmailBurstDoesNotTripOutlook simulates a burst of 429/5xx-style
+// failures against the gmail key and verifies its breaker trips open while
+// an unrelated outlook key is unaffected.
+func TestGmailBurstDoesNotTripOutlook(t *testing.T) {
+	r := NewRegistry(testConfig())
+
+	gmailKey := Key{Provider: "gmail", Operation: "list_emails"}
+	outlookKey := Key{Provider: "outlook", Operation: "get_emails"}
+
+	failing := func() (interface{}, error) {
+		return nil, errors.New("429 Too Many Requests")
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := r.Execute(gmailKey, failing); err == nil {
+			t.Fatalf("expected failing call %d to return an error", i)
+		}
+	}
+
+	if got := r.State(gmailKey); got != gobreaker.StateOpen {
+		t.Fatalf("expected gmail breaker to be open after 3 consecutive failures, got %v", got)
+	}
+
+	succeeding := func() (interface{}, error) { return "ok", nil }
+	for i := 0; i < 5; i++ {
+		if _, err := r.Execute(outlookKey, succeeding); err != nil {
+			t.Fatalf("outlook call %d should not be affected by gmail's breaker: %v", i, err)
+		}
+	}
+
+	if got := r.State(outlookKey); got != gobreaker.StateClosed {
+		t.Fatalf("expected outlook breaker to remain closed, got %v", got)
+	}
+}
+
+// TestPageSizeSlowStartAfterRecovery verifies that once a breaker closes
+// again after a trip, PageSize returns the provider's reduced slow-start
+// value until the configured number of successful calls have completed.
+func TestPageSizeSlowStartAfterRecovery(t *testing.T) {
+	cfg := testConfig()
+	cfg.Gmail.Timeout = time.Millisecond // gobreaker replaces a zero Timeout with its 60s default
+	r := NewRegistry(cfg)
+
+	key := Key{Provider: "gmail", Operation: "list_emails"}
+	failing := func() (interface{}, error) { return nil, errors.New("503 Service Unavailable") }
+	succeeding := func() (interface{}, error) { return "ok", nil }
+
+	for i := 0; i < 3; i++ {
+		r.Execute(key, failing)
+	}
+	if got := r.State(key); got != gobreaker.StateOpen {
+		t.Fatalf("expected breaker to be open, got %v", got)
+	}
+
+	// Once the 1ms Timeout has elapsed, the next Execute call finds the
+	// breaker half-open and, on success, closes it and starts the
+	// slow-start window.
+	time.Sleep(cfg.Gmail.Timeout * 5)
+	if _, err := r.Execute(key, succeeding); err != nil {
+		t.Fatalf("expected half-open probe to succeed: %v", err)
+	}
+	if got := r.State(key); got != gobreaker.StateClosed {
+		t.Fatalf("expected breaker to close after a successful probe, got %v", got)
+	}
+
+	if got := r.PageSize(key, 100); got != cfg.Gmail.SlowStartPageSize {
+		t.Fatalf("expected slow-start page size %d right after recovery, got %d", cfg.Gmail.SlowStartPageSize, got)
+	}
+
+	for i := 0; i < cfg.Gmail.SlowStartRequests-1; i++ {
+		r.Execute(key, succeeding)
+	}
+	if got := r.PageSize(key, 100); got != 100 {
+		t.Fatalf("expected configured page size 100 after the slow-start window elapsed, got %d", got)
+	}
+}
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"io"
+)
+
+func (s *Store) compress(data []byte) ([]byte, error) {
+	if !s.cfg.Compress {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, fmt.Errorf("cache: gzip compression failed: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("cache: gzip compression failed: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *Store) decompress(data []byte) ([]byte, error) {
+	if !s.cfg.Compress {
+		return data, nil
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("cache: gzip decompression failed: %w", err)
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func encrypt(key, plain []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("cache: failed to generate nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plain, nil), nil
+}
+
+func decrypt(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, fmt.Errorf("cache: encrypted entry is too short")
+	}
+	nonce, ciphertext := sealed[:nonceSize], sealed[nonceSize:]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("cache: failed to initialize AES cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("cache: failed to initialize AES-GCM: %w", err)
+	}
+	return gcm, nil
+}
@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/sys/unix" // v0.13.0
+)
+
+// evictionLoop runs evictForCapacity on cfg.EvictionInterval until Stop
+// is called.
+func (s *Store) evictionLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.cfg.EvictionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.evictForCapacity()
+		}
+	}
+}
+
+// evictForCapacity removes the least-recently-used cache entries until
+// the store is back under cfg.MaxBytes and above cfg.MinFreeBytes, or
+// there's nothing left to evict.
+func (s *Store) evictForCapacity() {
+	for {
+		overCap := s.cfg.MaxBytes > 0 && s.totalSize() > s.cfg.MaxBytes
+		underFree := s.cfg.MinFreeBytes > 0 && s.freeDiskBytes() < s.cfg.MinFreeBytes
+		if !overCap && !underFree {
+			return
+		}
+
+		path, ok := s.oldestEntry()
+		if !ok {
+			return
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			s.logger.Warn("cache: failed to evict entry", "path", path, "error", err)
+			return
+		}
+
+		reason := "max_bytes"
+		if underFree {
+			reason = "min_free_bytes"
+		}
+		cacheEvictionsTotal.WithLabelValues(reason).Inc()
+	}
+}
+
+// totalSize returns the cache's current total on-disk size, across every
+// user.
+func (s *Store) totalSize() int64 {
+	var total int64
+	filepath.WalkDir(s.cfg.BaseDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total
+}
+
+// freeDiskBytes returns the free space available on the volume holding
+// cfg.BaseDir.
+func (s *Store) freeDiskBytes() int64 {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(s.cfg.BaseDir, &stat); err != nil {
+		return -1
+	}
+	return int64(stat.Bavail) * int64(stat.Bsize)
+}
+
+// oldestEntry returns the path of the least-recently-used cache entry
+// (by mtime, bumped on every cache hit) across every user.
+func (s *Store) oldestEntry() (string, bool) {
+	var oldestPath string
+	var oldestTime time.Time
+
+	filepath.WalkDir(s.cfg.BaseDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if oldestPath == "" || info.ModTime().Before(oldestTime) {
+			oldestPath = path
+			oldestTime = info.ModTime()
+		}
+		return nil
+	})
+
+	return oldestPath, oldestPath != ""
+}
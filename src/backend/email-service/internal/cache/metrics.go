@@ -0,0 +1,28 @@
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus" // v1.17.0
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	cacheResultsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "disk_cache_results_total",
+		Help: "Total number of disk cache lookups by blob kind and outcome",
+	}, []string{"kind", "result"})
+
+	cacheWritesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "disk_cache_writes_total",
+		Help: "Total number of blobs written to the disk cache by kind",
+	}, []string{"kind"})
+
+	cacheEvictionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "disk_cache_evictions_total",
+		Help: "Total number of disk cache entries evicted by reason",
+	}, []string{"reason"})
+
+	prepopulateSkippedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "disk_cache_prepopulate_skipped_total",
+		Help: "Total number of Prepopulate calls skipped because the concurrency limit was reached",
+	})
+)
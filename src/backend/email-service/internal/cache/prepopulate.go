@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// defaultPrepopulateTimeout bounds how long a single Prepopulate call may
+// spend writing its message and attachment blobs.
+const defaultPrepopulateTimeout = time.Second * 30
+
+// Prepopulate asynchronously caches a recently processed message (and any
+// attachment bodies already available for it) before anyone has asked to
+// read it, bounded by cfg.PrepopulateConcurrency so a burst of newly
+// processed mail can't spawn unbounded goroutines. It's best-effort and
+// non-blocking: if the store is already at its concurrency limit, the
+// message is skipped rather than queued, since Prepopulate only exists to
+// warm the cache, not to guarantee every message ends up in it.
+func (s *Store) Prepopulate(userID, messageID string, rfc822 []byte, attachments map[string][]byte) {
+	select {
+	case s.sem <- struct{}{}:
+	default:
+		prepopulateSkippedTotal.Inc()
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() { <-s.sem }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), defaultPrepopulateTimeout)
+		defer cancel()
+
+		if err := s.PutMessage(ctx, userID, messageID, rfc822); err != nil {
+			s.logger.Warn("cache: prepopulate failed to cache message", "message_id", messageID, "error", err)
+		}
+		for attachmentID, body := range attachments {
+			if err := s.PutAttachment(ctx, userID, attachmentID, body); err != nil {
+				s.logger.Warn("cache: prepopulate failed to cache attachment", "attachment_id", attachmentID, "error", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,259 @@
+// Package cache provides an on-disk, per-user-encrypted cache of email
+// bodies and attachment blobs, keyed by MessageID/AttachmentID, with a
+// background evictor that enforces a configurable size cap and
+// minimum-free-disk-space threshold.
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	defaultEvictionInterval       = time.Minute * 5
+	defaultPrepopulateConcurrency = 4
+	userKeySize                   = 32 // AES-256
+)
+
+// blobKind distinguishes the two cache namespaces a Store keys entries
+// under.
+type blobKind string
+
+const (
+	kindMessage    blobKind = "messages"
+	kindAttachment blobKind = "attachments"
+)
+
+// PrimaryStore is the fallback source GetRFC822/GetBody fetch from when
+// the cache has no entry for a message or attachment.
+type PrimaryStore interface {
+	FetchRFC822(ctx context.Context, messageID string) ([]byte, error)
+	FetchAttachmentBody(ctx context.Context, attachmentID string) ([]byte, error)
+}
+
+// Config controls where Store persists blobs, how they're protected, and
+// when its background evictor runs.
+type Config struct {
+	// BaseDir is the root directory blobs are stored under, one
+	// subdirectory per user.
+	BaseDir string
+	// MaxBytes caps the cache's total on-disk size; 0 means unbounded
+	// (subject only to MinFreeBytes).
+	MaxBytes int64
+	// MinFreeBytes is the minimum free space BaseDir's volume must
+	// retain; once free space falls below this, the background evictor's
+	// next pass evicts the least-recently-used entries. Writes themselves
+	// do not evict.
+	MinFreeBytes int64
+	// EvictionInterval is how often the background evictor checks
+	// MaxBytes/MinFreeBytes. Defaults to defaultEvictionInterval.
+	EvictionInterval time.Duration
+	// PrepopulateConcurrency bounds how many Prepopulate calls may fetch
+	// and cache a blob at once. Defaults to
+	// defaultPrepopulateConcurrency.
+	PrepopulateConcurrency int
+	// Compress gzip-compresses blobs before encryption.
+	Compress bool
+}
+
+// Store is an on-disk cache of message bodies and attachment blobs. Each
+// user's blobs are encrypted with their own randomized AES-256 key,
+// generated on first use and held only in memory, so ClearUser both
+// removes a user's files and makes any copy left behind permanently
+// undecryptable.
+type Store struct {
+	cfg    Config
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	userKeys map[string][]byte
+
+	sem      chan struct{}
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewStore creates a Store rooted at cfg.BaseDir, creating the directory
+// if necessary. logger may be nil, in which case slog.Default() is used.
+func NewStore(cfg Config, logger *slog.Logger) (*Store, error) {
+	if cfg.BaseDir == "" {
+		return nil, fmt.Errorf("cache: base directory is required")
+	}
+	if cfg.EvictionInterval <= 0 {
+		cfg.EvictionInterval = defaultEvictionInterval
+	}
+	if cfg.PrepopulateConcurrency <= 0 {
+		cfg.PrepopulateConcurrency = defaultPrepopulateConcurrency
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if err := os.MkdirAll(cfg.BaseDir, 0o700); err != nil {
+		return nil, fmt.Errorf("cache: failed to create base directory: %w", err)
+	}
+
+	return &Store{
+		cfg:      cfg,
+		logger:   logger,
+		userKeys: make(map[string][]byte),
+		sem:      make(chan struct{}, cfg.PrepopulateConcurrency),
+		stopCh:   make(chan struct{}),
+	}, nil
+}
+
+// Start launches the background evictor. Stop must be called to release
+// it.
+func (s *Store) Start() {
+	s.wg.Add(1)
+	go s.evictionLoop()
+}
+
+// Stop halts the background evictor and waits for any in-flight
+// Prepopulate calls to finish.
+func (s *Store) Stop() {
+	s.stopOnce.Do(func() { close(s.stopCh) })
+	s.wg.Wait()
+}
+
+// PutMessage caches a message's full RFC822 body for userID.
+func (s *Store) PutMessage(ctx context.Context, userID, messageID string, body []byte) error {
+	return s.put(userID, kindMessage, messageID, body)
+}
+
+// GetMessage returns a previously cached RFC822 body for userID, or
+// found=false on a cache miss.
+func (s *Store) GetMessage(ctx context.Context, userID, messageID string) (body []byte, found bool, err error) {
+	return s.get(userID, kindMessage, messageID)
+}
+
+// PutAttachment caches an attachment's raw body for userID.
+func (s *Store) PutAttachment(ctx context.Context, userID, attachmentID string, body []byte) error {
+	return s.put(userID, kindAttachment, attachmentID, body)
+}
+
+// GetAttachment returns a previously cached attachment body for userID,
+// or found=false on a cache miss.
+func (s *Store) GetAttachment(ctx context.Context, userID, attachmentID string) (body []byte, found bool, err error) {
+	return s.get(userID, kindAttachment, attachmentID)
+}
+
+// ClearUser deletes userID's cached blobs and its in-memory key, so any
+// file left behind by a racing write is permanently undecryptable.
+func (s *Store) ClearUser(userID string) error {
+	s.mu.Lock()
+	delete(s.userKeys, userID)
+	s.mu.Unlock()
+
+	if err := os.RemoveAll(s.userDir(userID)); err != nil {
+		return fmt.Errorf("cache: failed to clear user %q: %w", userID, err)
+	}
+	return nil
+}
+
+func (s *Store) put(userID string, kind blobKind, id string, body []byte) error {
+	compressed, err := s.compress(body)
+	if err != nil {
+		return err
+	}
+
+	ciphertext, err := encrypt(s.keyFor(userID), compressed)
+	if err != nil {
+		return err
+	}
+
+	path := s.pathFor(userID, kind, id)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("cache: failed to create cache directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, ciphertext, 0o600); err != nil {
+		return fmt.Errorf("cache: failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("cache: failed to commit cache entry: %w", err)
+	}
+
+	cacheWritesTotal.WithLabelValues(string(kind)).Inc()
+	return nil
+}
+
+func (s *Store) get(userID string, kind blobKind, id string) ([]byte, bool, error) {
+	path := s.pathFor(userID, kind, id)
+
+	ciphertext, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			cacheResultsTotal.WithLabelValues(string(kind), "miss").Inc()
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("cache: failed to read cache entry: %w", err)
+	}
+
+	compressed, err := decrypt(s.keyFor(userID), ciphertext)
+	if err != nil {
+		// Most likely the user's in-memory key was reset (process
+		// restart, or a concurrent ClearUser) and the entry is now
+		// permanently undecryptable: evict it and report a miss rather
+		// than an error, since a cache is always allowed to miss.
+		os.Remove(path)
+		cacheResultsTotal.WithLabelValues(string(kind), "miss").Inc()
+		return nil, false, nil
+	}
+
+	body, err := s.decompress(compressed)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now()
+	os.Chtimes(path, now, now) // bump mtime so the LRU evictor treats this as recently used
+
+	cacheResultsTotal.WithLabelValues(string(kind), "hit").Inc()
+	return body, true, nil
+}
+
+// keyFor returns userID's AES-256 key, generating and remembering a new
+// random one on first use.
+func (s *Store) keyFor(userID string) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key, ok := s.userKeys[userID]; ok {
+		return key
+	}
+
+	key := make([]byte, userKeySize)
+	if _, err := rand.Read(key); err != nil {
+		// crypto/rand failing means the system's entropy source is
+		// broken; there's no sane fallback, so fail loudly like the
+		// standard library itself recommends for rand.Read errors.
+		panic(fmt.Sprintf("cache: failed to generate user key: %v", err))
+	}
+	s.userKeys[userID] = key
+	return key
+}
+
+func (s *Store) userDir(userID string) string {
+	return filepath.Join(s.cfg.BaseDir, fsSafeKey(userID))
+}
+
+func (s *Store) pathFor(userID string, kind blobKind, id string) string {
+	return filepath.Join(s.userDir(userID), string(kind), fsSafeKey(id)+".bin")
+}
+
+// fsSafeKey maps an arbitrary ID (which may contain characters unsafe for
+// a filename) to a fixed-width hex digest.
+func fsSafeKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
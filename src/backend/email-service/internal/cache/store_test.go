@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestStoreRoundTripPreservesChecksum verifies a blob's checksum survives
+// the compress -> encrypt -> decrypt -> decompress round trip unchanged.
+func TestStoreRoundTripPreservesChecksum(t *testing.T) {
+	store, err := NewStore(Config{BaseDir: t.TempDir(), Compress: true}, nil)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	body := bytes.Repeat([]byte("the quick brown fox jumps over the lazy dog"), 100)
+	want := sha256.Sum256(body)
+
+	if err := store.PutMessage(context.Background(), "user-1", "msg-1", body); err != nil {
+		t.Fatalf("PutMessage failed: %v", err)
+	}
+
+	got, found, err := store.GetMessage(context.Background(), "user-1", "msg-1")
+	if err != nil {
+		t.Fatalf("GetMessage failed: %v", err)
+	}
+	if !found {
+		t.Fatal("expected a cache hit")
+	}
+
+	if sha256.Sum256(got) != want {
+		t.Fatal("checksum mismatch after round trip")
+	}
+}
+
+// TestStoreConcurrentReads verifies many goroutines can read the same
+// cached entry concurrently without error or data corruption.
+func TestStoreConcurrentReads(t *testing.T) {
+	store, err := NewStore(Config{BaseDir: t.TempDir()}, nil)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	body := []byte("concurrent body")
+	if err := store.PutMessage(context.Background(), "user-1", "msg-1", body); err != nil {
+		t.Fatalf("PutMessage failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, found, err := store.GetMessage(context.Background(), "user-1", "msg-1")
+			if err != nil {
+				errs <- err
+				return
+			}
+			if !found || !bytes.Equal(got, body) {
+				errs <- fmt.Errorf("unexpected read result: found=%v body=%q", found, got)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent read failed: %v", err)
+	}
+}
+
+// TestStoreEvictsUnderPressure verifies evictForCapacity removes the
+// least-recently-used entries once the store exceeds MaxBytes.
+func TestStoreEvictsUnderPressure(t *testing.T) {
+	store, err := NewStore(Config{BaseDir: t.TempDir(), MaxBytes: 1}, nil)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		id := fmt.Sprintf("msg-%d", i)
+		if err := store.PutMessage(context.Background(), "user-1", id, []byte("some message body")); err != nil {
+			t.Fatalf("PutMessage failed: %v", err)
+		}
+		time.Sleep(time.Millisecond) // keep write-order distinguishable by mtime
+	}
+
+	store.evictForCapacity()
+
+	if got := store.totalSize(); got > 1 {
+		_, found, err := store.GetMessage(context.Background(), "user-1", "msg-0")
+		if err != nil {
+			t.Fatalf("GetMessage failed: %v", err)
+		}
+		if found {
+			t.Fatalf("expected the oldest entry to have been evicted, store size is still %d bytes", got)
+		}
+	}
+}
+
+// TestStoreClearUserMakesEntriesUnreadable verifies ClearUser removes a
+// user's cached files and its in-memory key together.
+func TestStoreClearUserMakesEntriesUnreadable(t *testing.T) {
+	store, err := NewStore(Config{BaseDir: t.TempDir()}, nil)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	if err := store.PutMessage(context.Background(), "user-1", "msg-1", []byte("body")); err != nil {
+		t.Fatalf("PutMessage failed: %v", err)
+	}
+
+	if err := store.ClearUser("user-1"); err != nil {
+		t.Fatalf("ClearUser failed: %v", err)
+	}
+
+	_, found, err := store.GetMessage(context.Background(), "user-1", "msg-1")
+	if err != nil {
+		t.Fatalf("GetMessage failed: %v", err)
+	}
+	if found {
+		t.Fatal("expected a cache miss after ClearUser")
+	}
+}
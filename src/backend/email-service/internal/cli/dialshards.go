@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra" // v1.8.0
+)
+
+// newDialShardsCmd health-checks every shard listed in ShardConfig with a
+// timeout and prints the results as a table. It shares pingShard with
+// sql-ping but is intended as the quick go/no-go check before a deploy.
+func newDialShardsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "dial-shards",
+		Short: "Health-check every shard in ShardConfig with a timeout",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDialShards(cmd.Context())
+		},
+	}
+}
+
+func runDialShards(ctx context.Context) error {
+	shards := current.cfg.Shards.Shards
+	if len(shards) == 0 {
+		fmt.Println("no shards configured")
+		return nil
+	}
+
+	fmt.Printf("%-10s %-15s %s\n", "SHARD", "STATUS", "LATENCY")
+	var unreachable int
+	for _, shard := range shards {
+		status, latency, err := pingShard(ctx, shard.DataSourceName)
+		if err != nil {
+			unreachable++
+		}
+		fmt.Printf("%-10d %-15s %s\n", shard.ShardID, status, latency)
+	}
+
+	if unreachable > 0 {
+		return fmt.Errorf("%d of %d shard(s) unreachable", unreachable, len(shards))
+	}
+	return nil
+}
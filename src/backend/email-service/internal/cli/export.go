@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq" // v1.10.9
+	"github.com/spf13/cobra" // v1.8.0
+
+	"github.com/email-management-platform/backend/email-service/internal/export"
+	"github.com/email-management-platform/backend/email-service/internal/repositories"
+)
+
+// newExportRunCmd runs one internal/export daily compliance archive pass
+// and exits, for an external scheduler (e.g. a Kubernetes CronJob) to
+// invoke daily rather than running export.DailyRunner as an in-process
+// timer. It's a no-op when export.output_dir isn't set, since the
+// subsystem is opt-in.
+func newExportRunCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export-run",
+		Short: "Run one compliance export pass of every email sent or received since the last run",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExportRun(cmd.Context())
+		},
+	}
+}
+
+func runExportRun(ctx context.Context) error {
+	cfg := current.cfg.Export
+	if cfg.OutputDir == "" {
+		fmt.Println("export not configured (export.output_dir is empty), skipping")
+		return nil
+	}
+	if len(current.cfg.Shards.Shards) == 0 {
+		return fmt.Errorf("export is configured but no database shard is available to read from")
+	}
+
+	db, err := sql.Open("postgres", current.cfg.Shards.Shards[0].DataSourceName)
+	if err != nil {
+		return fmt.Errorf("failed to open export database: %w", err)
+	}
+	defer db.Close()
+
+	source, err := repositories.NewEmailRepository(db, &current.cfg.Shards, current.logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize email repository: %w", err)
+	}
+	defer source.Close()
+
+	exporter, err := newConfiguredExporter(cfg.Format)
+	if err != nil {
+		return err
+	}
+
+	sink, err := export.NewLocalDiskSink(cfg.OutputDir)
+	if err != nil {
+		return fmt.Errorf("failed to initialize export output directory: %w", err)
+	}
+
+	since := cfg.FromTimestamp
+	if last, ok := readLastExportRun(cfg.StateFile); ok {
+		since = last
+	}
+
+	runner, err := export.NewDailyRunner(export.SchedulerConfig{ExportFromTimestamp: since}, source, exporter, sink, current.logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize export scheduler: %w", err)
+	}
+
+	runStart := time.Now()
+	if err := runner.RunOnce(ctx); err != nil {
+		return fmt.Errorf("export run failed: %w", err)
+	}
+
+	if err := writeLastExportRun(cfg.StateFile, runStart); err != nil {
+		current.logger.Error("export: failed to persist last run timestamp", "error", err)
+	}
+
+	fmt.Printf("export run completed, covering everything since %s\n", since.UTC().Format(time.RFC3339))
+	return nil
+}
+
+// newConfiguredExporter builds the export.Exporter named by format,
+// defaulting to CSVExporter when format is empty.
+func newConfiguredExporter(format string) (export.Exporter, error) {
+	switch format {
+	case "", "csv":
+		return export.NewCSVExporter(0), nil
+	case "eml":
+		return export.NewEMLExporter(export.LocalDiskAttachmentStore{}, 0)
+	case "globalrelay":
+		return export.NewGlobalRelayExporter(export.LocalDiskAttachmentStore{}, 0)
+	default:
+		return nil, fmt.Errorf("export: unknown format %q", format)
+	}
+}
+
+// readLastExportRun reads the timestamp left by a previous export-run
+// invocation from stateFile. It returns ok=false if stateFile is empty, the
+// file doesn't exist yet (the first run), or its contents can't be parsed.
+func readLastExportRun(stateFile string) (time.Time, bool) {
+	if stateFile == "" {
+		return time.Time{}, false
+	}
+
+	data, err := os.ReadFile(stateFile)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// writeLastExportRun persists runStart to stateFile for the next
+// export-run invocation to pick up as its lower bound. It's a no-op when
+// stateFile isn't configured.
+func writeLastExportRun(stateFile string, runStart time.Time) error {
+	if stateFile == "" {
+		return nil
+	}
+	return os.WriteFile(stateFile, []byte(runStart.UTC().Format(time.RFC3339)), 0o640)
+}
@@ -0,0 +1,96 @@
+// Package cli assembles the email service's operational command surface: the
+// long-running server, a handful of Praefect-style diagnostics for
+// operating the sharded Postgres fleet (sql-ping, sql-migrate, dial-shards,
+// dataloss), and export-run, a one-shot compliance archive pass meant to be
+// invoked by an external scheduler.
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra" // v1.8.0
+	"github.com/spf13/viper" // v1.17.0
+
+	"github.com/email-management-platform/backend/email-service/internal/config"
+	"github.com/email-management-platform/backend/email-service/internal/logging"
+)
+
+// app bundles the dependencies every subcommand needs, populated once in
+// rootCmd's PersistentPreRunE so subcommands never duplicate config/logger
+// wiring themselves.
+type app struct {
+	cfg    *config.Config
+	logger *slog.Logger
+}
+
+var (
+	cfgPath   string
+	env       string
+	logFormat string
+	logLevel  string
+
+	current app
+)
+
+// NewRootCmd builds the root Cobra command. Flags are bound through Viper so
+// EMAIL_SERVICE_-prefixed environment variables and the config file loaded
+// by config.LoadConfig continue to take precedence the same way they already
+// do for the server.
+func NewRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "email-service",
+		Short:         "Email service server and operational diagnostics",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return loadApp(cmd)
+		},
+	}
+
+	root.PersistentFlags().StringVar(&cfgPath, "config", ".", "path to the configuration directory")
+	root.PersistentFlags().StringVar(&env, "env", os.Getenv("ENV"), "deployment environment")
+	root.PersistentFlags().StringVar(&logFormat, "log.format", "json", "log output format: json|logfmt")
+	root.PersistentFlags().StringVar(&logLevel, "log.level", "info", "log level: debug|info|warn|error")
+
+	_ = viper.BindPFlag("config", root.PersistentFlags().Lookup("config"))
+	_ = viper.BindPFlag("env", root.PersistentFlags().Lookup("env"))
+
+	root.AddCommand(
+		newServeCmd(),
+		newSQLPingCmd(),
+		newSQLMigrateCmd(),
+		newSQLMigrateStatusCmd(),
+		newDialShardsCmd(),
+		newDatalossCmd(),
+		newExportRunCmd(),
+	)
+
+	return root
+}
+
+// Execute runs the command tree; main.go's sole job is to call this.
+func Execute() error {
+	return NewRootCmd().Execute()
+}
+
+// loadApp builds the shared config + logger once per invocation and stashes
+// them in `current` for subcommands to read.
+func loadApp(cmd *cobra.Command) error {
+	logger, err := logging.New(logging.Options{
+		Format: logging.Format(logFormat),
+		Level:  logLevel,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize logger: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(cfgPath, env)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	current = app{cfg: cfg, logger: logger}
+	return nil
+}
@@ -0,0 +1,341 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	_ "github.com/lib/pq" // v1.10.9
+	"github.com/prometheus/client_golang/prometheus" // v1.17.0
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/spf13/cobra" // v1.8.0
+
+	"github.com/email-management-platform/backend/email-service/cmd/server"
+	"github.com/email-management-platform/backend/email-service/internal/config"
+	"github.com/email-management-platform/backend/email-service/internal/gmailwatch"
+	"github.com/email-management-platform/backend/email-service/internal/incoming"
+	"github.com/email-management-platform/backend/email-service/internal/models"
+	"github.com/email-management-platform/backend/email-service/internal/repositories"
+	"github.com/email-management-platform/backend/email-service/internal/services"
+)
+
+const (
+	defaultStartupRetries  = 3
+	defaultRetryDelay      = time.Second * 5
+	defaultShutdownTimeout = time.Second * 30
+)
+
+var (
+	serverStartupTime = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "email_service_startup_timestamp",
+		Help: "Timestamp when the server started",
+	})
+
+	serverShutdownTime = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "email_service_shutdown_timestamp",
+		Help: "Timestamp when the server shut down",
+	})
+
+	startupAttempts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "email_service_startup_attempts_total",
+		Help: "Total number of server startup attempts",
+	})
+
+	startupErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "email_service_startup_errors_total",
+		Help: "Total number of server startup errors",
+	})
+)
+
+// newServeCmd runs the HTTP/gRPC/metrics server, the email service's default
+// behavior prior to this command tree existing.
+func newServeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Run the email service HTTP, gRPC, and metrics servers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe(cmd.Context())
+		},
+	}
+}
+
+func runServe(ctx context.Context) error {
+	logger := current.logger
+
+	srv, err := initializeServer(current.cfg, logger)
+	if err != nil {
+		return err
+	}
+
+	serverStartupTime.SetToCurrentTime()
+
+	// batchProcessor, when enabled, is shared by every incoming-mail
+	// subsystem below so a single noisy account's retries can never starve
+	// another subsystem's submissions.
+	batchProcessor := initializeBatchProcessor(current.cfg, srv, logger)
+
+	// initializeGmailWatch registers nothing on srv's gRPC server: it reuses
+	// srv's email handler, which NewServer already registered there.
+	watchManager, err := initializeGmailWatch(ctx, current.cfg, srv, batchProcessor, logger)
+	if err != nil {
+		logger.Error("gmailwatch: failed to start, continuing without it", "error", err)
+	}
+
+	stopIncomingFetcher, err := initializeIncomingFetcher(ctx, current.cfg, srv, batchProcessor, logger)
+	if err != nil {
+		logger.Error("incoming: failed to start, continuing without it", "error", err)
+	}
+
+	go func() {
+		if err := srv.Start(); err != nil {
+			logger.Error("server error", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT)
+
+	sig := <-sigChan
+	logger.Info("received shutdown signal", "signal", sig.String())
+
+	serverShutdownTime.SetToCurrentTime()
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, defaultShutdownTimeout)
+	defer cancel()
+
+	if watchManager != nil {
+		// SIGTERM/SIGINT/SIGQUIT here mean a rolling restart, not a
+		// decommission, so existing Gmail watches are left in place rather
+		// than unwatched.
+		if err := watchManager.Stop(shutdownCtx, false); err != nil {
+			logger.Error("gmailwatch: error during shutdown", "error", err)
+		}
+	}
+
+	if stopIncomingFetcher != nil {
+		stopIncomingFetcher()
+	}
+
+	if batchProcessor != nil {
+		batchProcessor.Stop()
+	}
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	logger.Info("server shutdown completed successfully")
+	return nil
+}
+
+// initializeGmailWatch starts the gmailwatch subsystem alongside the
+// server when Gmail Pub/Sub is configured. It returns (nil, nil) when
+// gmail.pubsub_topic/pubsub_subscription aren't set, since the subsystem
+// is opt-in.
+func initializeGmailWatch(ctx context.Context, cfg *config.Config, srv *server.Server, batchProcessor *services.BatchProcessor, logger *slog.Logger) (*gmailwatch.Manager, error) {
+	if cfg.Gmail.PubSubTopic == "" || cfg.Gmail.PubSubSubscription == "" {
+		return nil, nil
+	}
+	if len(cfg.Shards.Shards) == 0 {
+		return nil, fmt.Errorf("gmail pub/sub is configured but no database shard is available to track watch state")
+	}
+
+	// gmail_watches is a small control-plane table, not sharded email
+	// volume, so it lives alongside the first configured shard.
+	db, err := sql.Open("postgres", cfg.Shards.Shards[0].DataSourceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gmail watch database: %w", err)
+	}
+
+	store, err := repositories.NewGmailWatchRepository(db, logger)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	subscriber, err := gmailwatch.NewPubSubSubscriber(ctx, cfg.Gmail)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	// Reuse srv's already-constructed email handler, which is already
+	// registered on srv's gRPC server; constructing a second one here and
+	// registering it again would panic (grpc.Server rejects a duplicate
+	// RegisterService call for the same service).
+	emailHandler := srv.EmailHandler()
+
+	// No mailbox clients are passed, so the subsystem starts with no
+	// mailboxes watched; Manager has no way to add one after construction.
+	processor := &notifyingEmailProcessor{emailService: srv.EmailService(), batch: batchProcessor, notifier: emailHandler}
+	manager, err := gmailwatch.NewManager(cfg.Gmail, store, processor, subscriber, nil, logger)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if err := manager.Start(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return manager, nil
+}
+
+// initializeIncomingFetcher starts an IMAP Fetcher polling
+// cfg.Incoming.Mailbox alongside the server, handing every message it
+// parses to the same EmailService the HTTP/gRPC handlers use. It returns
+// a nil cancel func when incoming.host isn't set, since the subsystem is
+// opt-in. The caller must invoke the returned func during shutdown.
+func initializeIncomingFetcher(ctx context.Context, cfg *config.Config, srv *server.Server, batchProcessor *services.BatchProcessor, logger *slog.Logger) (func(), error) {
+	if cfg.Incoming.Host == "" {
+		return nil, nil
+	}
+
+	client, err := incoming.DialMailbox(incoming.IMAPConfig{
+		Host:     cfg.Incoming.Host,
+		Port:     cfg.Incoming.Port,
+		Username: cfg.Incoming.Username,
+		Password: cfg.Incoming.Password,
+		TLSMode:  incomingTLSMode(cfg.Incoming.TLSMode),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to incoming mailbox: %w", err)
+	}
+
+	var processor incoming.EmailProcessor = srv.EmailService()
+	if batchProcessor != nil {
+		processor = &batchEmailProcessor{batch: batchProcessor}
+	}
+
+	fetcher, err := incoming.NewFetcher(incoming.Config{
+		Mailbox:      cfg.Incoming.Mailbox,
+		PollInterval: cfg.Incoming.PollInterval,
+		AccountID:    cfg.Incoming.AccountID,
+		ReplyDomain:  cfg.Mail.ReplyDomain,
+		ReplySecret:  []byte(cfg.Mail.ReplySecret),
+	}, client, processor, nil, logger)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	fetchCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		if err := fetcher.Run(fetchCtx); err != nil && fetchCtx.Err() == nil {
+			logger.Error("incoming: fetcher stopped", "error", err)
+		}
+	}()
+
+	return cancel, nil
+}
+
+// incomingTLSMode maps an incoming.tls_mode config string to the
+// incoming.TLSMode DialMailbox expects, defaulting to TLSImplicit for an
+// empty or unrecognized value.
+func incomingTLSMode(mode string) incoming.TLSMode {
+	switch mode {
+	case "starttls":
+		return incoming.TLSStartTLS
+	case "none":
+		return incoming.TLSNone
+	default:
+		return incoming.TLSImplicit
+	}
+}
+
+// initializeBatchProcessor starts a services.BatchProcessor shared by
+// every incoming-mail subsystem (gmailwatch, the IMAP Fetcher) when
+// batch_processing.enabled is set, so a burst of incoming mail is
+// retried with backoff and dead-lettered instead of being processed
+// inline on the subsystem's own poll/notification goroutine. Returns nil
+// when disabled, the original inline-processing behavior.
+func initializeBatchProcessor(cfg *config.Config, srv *server.Server, logger *slog.Logger) *services.BatchProcessor {
+	if !cfg.BatchProcessing.Enabled {
+		return nil
+	}
+
+	processor, err := services.NewBatchProcessor(srv.EmailService(), nil, services.BatchProcessorOptions{
+		Workers:        cfg.BatchProcessing.Workers,
+		QueueSize:      cfg.BatchProcessing.QueueSize,
+		MaxConcurrency: cfg.BatchProcessing.MaxConcurrency,
+		MaxAttempts:    cfg.BatchProcessing.MaxAttempts,
+		BaseBackoff:    cfg.BatchProcessing.BaseBackoff,
+		MaxBackoff:     cfg.BatchProcessing.MaxBackoff,
+	})
+	if err != nil {
+		logger.Error("batch processor: failed to start, continuing without it", "error", err)
+		return nil
+	}
+
+	processor.Start()
+	return processor
+}
+
+// batchEmailProcessor adapts a *services.BatchProcessor to the
+// single-call ProcessEmail contract gmailwatch/incoming expect of their
+// processor.
+type batchEmailProcessor struct {
+	batch *services.BatchProcessor
+}
+
+func (p *batchEmailProcessor) ProcessEmail(ctx context.Context, email *models.Email) error {
+	return p.batch.Submit(email)
+}
+
+// notifyingEmailProcessor adapts a services.EmailService (or, when batch
+// processing is enabled, a services.BatchProcessor submission) and a
+// gmailwatch.EmailNotifier into the single value gmailwatch.Manager
+// expects as its processor, so a successfully processed change is both
+// persisted through the normal pipeline and fanned out to gRPC
+// WatchEmails subscribers.
+type notifyingEmailProcessor struct {
+	emailService *services.EmailService
+	batch        *services.BatchProcessor
+	notifier     gmailwatch.EmailNotifier
+}
+
+func (p *notifyingEmailProcessor) ProcessEmail(ctx context.Context, email *models.Email) error {
+	if p.batch != nil {
+		return p.batch.Submit(email)
+	}
+	return p.emailService.ProcessEmail(ctx, email)
+}
+
+func (p *notifyingEmailProcessor) NotifyEmail(email *models.Email) {
+	p.notifier.NotifyEmail(email)
+}
+
+// initializeServer attempts to initialize the server with retries.
+func initializeServer(cfg *config.Config, logger *slog.Logger) (*server.Server, error) {
+	var srv *server.Server
+	var err error
+
+	for attempt := 1; attempt <= defaultStartupRetries; attempt++ {
+		startupAttempts.Inc()
+
+		srv, err = server.NewServer(cfg, logger)
+		if err == nil {
+			return srv, nil
+		}
+
+		startupErrors.Inc()
+		logger.Warn("server initialization attempt failed",
+			"error", err,
+			"attempt", attempt,
+			"max_attempts", defaultStartupRetries,
+		)
+
+		if attempt < defaultStartupRetries {
+			time.Sleep(defaultRetryDelay * time.Duration(attempt))
+		}
+	}
+
+	return nil, err
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/lib/pq" // v1.10.9
+	"github.com/spf13/cobra" // v1.8.0
+)
+
+const sqlPingTimeout = time.Second * 5
+
+// newSQLPingCmd verifies connectivity to every shard in config.ShardConfig.
+func newSQLPingCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sql-ping",
+		Short: "Verify each shard connection from ShardConfig",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSQLPing(cmd.Context())
+		},
+	}
+}
+
+func runSQLPing(ctx context.Context) error {
+	shards := current.cfg.Shards.Shards
+	if len(shards) == 0 {
+		fmt.Println("no shards configured")
+		return nil
+	}
+
+	fmt.Printf("%-10s %-10s %s\n", "SHARD", "STATUS", "LATENCY")
+	var failed int
+	for _, shard := range shards {
+		status, latency, err := pingShard(ctx, shard.DataSourceName)
+		if err != nil {
+			failed++
+		}
+		fmt.Printf("%-10d %-10s %s\n", shard.ShardID, status, latency)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d shard(s) failed to ping", failed, len(shards))
+	}
+	return nil
+}
+
+// pingShard opens a short-lived connection to dsn and pings it, returning a
+// human-readable status and latency for table output.
+func pingShard(ctx context.Context, dsn string) (status string, latency time.Duration, err error) {
+	pingCtx, cancel := context.WithTimeout(ctx, sqlPingTimeout)
+	defer cancel()
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return "error", 0, fmt.Errorf("failed to open connection: %w", err)
+	}
+	defer db.Close()
+
+	start := time.Now()
+	if err := db.PingContext(pingCtx); err != nil {
+		return "unreachable", time.Since(start), fmt.Errorf("failed to ping: %w", err)
+	}
+
+	return "ok", time.Since(start), nil
+}
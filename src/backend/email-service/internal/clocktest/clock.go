@@ -0,0 +1,105 @@
+// Package clocktest provides a Clock abstraction for code that schedules
+// delays (retry backoff, rate limiting), plus a FakeClock so tests can
+// advance time deterministically instead of sleeping through real delays.
+package clocktest
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstracts the subset of the time package callers need for
+// scheduling delays, so production code can depend on it instead of the
+// time package directly and tests can substitute a FakeClock.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+}
+
+// realClock implements Clock with the real time package.
+type realClock struct{}
+
+// NewRealClock returns the Clock production code should use by default.
+func NewRealClock() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time                        { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Sleep(d time.Duration)                 { time.Sleep(d) }
+
+// waiter is a pending After call, fired once the FakeClock's time reaches
+// or passes deadline.
+type waiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// FakeClock is a Clock whose Now only advances when Advance is called
+// explicitly, letting retry/backoff tests exercise multi-second delays
+// without actually waiting.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []waiter
+}
+
+// NewFakeClock creates a FakeClock starting at start.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now returns the clock's current simulated time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the simulated time once Advance
+// has moved the clock's time to or past c.Now()+d.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+
+	c.mu.Lock()
+	deadline := c.now.Add(d)
+	if !deadline.After(c.now) {
+		c.mu.Unlock()
+		ch <- deadline
+		return ch
+	}
+	c.waiters = append(c.waiters, waiter{deadline: deadline, ch: ch})
+	c.mu.Unlock()
+
+	return ch
+}
+
+// Sleep blocks until Advance moves the clock's time to or past c.Now()+d.
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// Advance moves the clock's time forward by d, firing every pending
+// After/Sleep waiter whose deadline has now been reached.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+
+	remaining := c.waiters[:0]
+	var fired []waiter
+	for _, w := range c.waiters {
+		if !w.deadline.After(c.now) {
+			fired = append(fired, w)
+		} else {
+			remaining = append(remaining, w)
+		}
+	}
+	c.waiters = remaining
+	now := c.now
+	c.mu.Unlock()
+
+	for _, w := range fired {
+		w.ch <- now
+	}
+}
@@ -0,0 +1,66 @@
+package clocktest
+
+import (
+	"testing"
+	"time"
+)
+
+// TestFakeClockAfterFiresOnAdvance verifies that After only fires once
+// Advance moves the clock's time to or past the requested deadline, and
+// not before.
+func TestFakeClockAfterFiresOnAdvance(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewFakeClock(start)
+
+	ch := clock.After(time.Second * 10)
+
+	select {
+	case <-ch:
+		t.Fatal("expected After channel to not fire before Advance")
+	default:
+	}
+
+	clock.Advance(time.Second * 5)
+	select {
+	case <-ch:
+		t.Fatal("expected After channel to not fire before its deadline")
+	default:
+	}
+
+	clock.Advance(time.Second * 5)
+	select {
+	case got := <-ch:
+		want := start.Add(time.Second * 10)
+		if !got.Equal(want) {
+			t.Fatalf("expected fired time %v, got %v", want, got)
+		}
+	default:
+		t.Fatal("expected After channel to fire once the clock reached its deadline")
+	}
+}
+
+// TestFakeClockSleepBlocksUntilAdvanced verifies Sleep only unblocks once
+// another goroutine advances the clock far enough.
+func TestFakeClockSleepBlocksUntilAdvanced(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+
+	done := make(chan struct{})
+	go func() {
+		clock.Sleep(time.Second)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("expected Sleep to block before the clock advanced")
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	clock.Advance(time.Second)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Sleep to unblock after the clock advanced past its deadline")
+	}
+}
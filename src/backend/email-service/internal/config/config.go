@@ -3,12 +3,15 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/spf13/viper" // v1.17.0
 	"golang.org/x/oauth2/google" // v0.13.0
+
+	"github.com/email-management-platform/backend/email-service/internal/secrets"
 )
 
 // Constants for configuration defaults and validation
@@ -18,8 +21,46 @@ const (
 	DefaultRequestTimeout    = time.Second * 30
 	DefaultShutdownTimeout   = time.Second * 10
 	DefaultWatchExpiryDuration = time.Hour * 24
+	DefaultDBScrapeTimeout     = time.Second * 5
 	MinPortNumber           = 1024
 	MaxPortNumber           = 65535
+
+	// Outbound mail dispatcher defaults
+	DefaultMailWorkerCount   = 4
+	DefaultMailBatchSize     = 50
+	DefaultMailRatePerSecond = 5.0
+	DefaultMailRateBurst     = 10
+	DefaultMailMaxRetries    = 5
+	DefaultMailRetryBackoff  = time.Second * 30
+
+	// Secret resolution defaults
+	DefaultSecretCacheTTL        = time.Minute * 5
+	DefaultSecretRefreshInterval = time.Minute * 10
+
+	// Per-visitor rate limiting defaults for EmailHandler. Send defaults
+	// are deliberately stricter than read defaults since POST /emails
+	// triggers outbound delivery rather than a local lookup.
+	DefaultReadRatePerSecond   = 20.0
+	DefaultReadBurst           = 40
+	DefaultSendRatePerSecond   = 2.0
+	DefaultSendBurst           = 5
+	DefaultGlobalRatePerSecond = 500.0
+	DefaultGlobalBurst         = 1000
+	DefaultVisitorTTL          = time.Minute * 10
+	DefaultVisitorCleanupInterval = time.Minute * 2
+
+	// DefaultPubSubAckDeadline bounds how long the gmailwatch subsystem has
+	// to process a Pub/Sub notification before it's redelivered.
+	DefaultPubSubAckDeadline = time.Second * 10
+
+	// Per-provider circuit breaker defaults. Thresholds are intentionally
+	// identical across providers out of the box; operators tune them per
+	// provider once they have real failure data.
+	DefaultBreakerMaxRequests         = uint32(5)
+	DefaultBreakerTimeout             = time.Second * 30
+	DefaultBreakerConsecutiveFailures = uint32(5)
+	DefaultBreakerSlowStartRequests   = 10
+	DefaultBreakerSlowStartPageSize   = 10
 )
 
 // Config represents the main configuration structure with enhanced security
@@ -36,6 +77,138 @@ type Config struct {
 	RequestTimeout  time.Duration `mapstructure:"request_timeout"`
 	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
 	Version         string        `mapstructure:"version"`
+	Shards          ShardConfig   `mapstructure:"shards"`
+	Mail            MailConfig    `mapstructure:"mail"`
+	RateLimit       RateLimitConfig `mapstructure:"rate_limit"`
+	Breakers        BreakerConfig `mapstructure:"breakers"`
+	BodyCache       BodyCacheConfig `mapstructure:"body_cache"`
+	Incoming        IncomingConfig `mapstructure:"incoming"`
+	BatchProcessing BatchProcessingConfig `mapstructure:"batch_processing"`
+	Export          ExportConfig  `mapstructure:"export"`
+}
+
+// ExportConfig configures the internal/export daily compliance archive
+// run, invoked via the "export-run" subcommand (e.g. from a Kubernetes
+// CronJob). The run is skipped when OutputDir is empty.
+type ExportConfig struct {
+	// Format selects the archive format: "csv" (default), "eml", or
+	// "globalrelay".
+	Format string `mapstructure:"format"`
+	// OutputDir is the local directory completed archives are written to.
+	OutputDir string `mapstructure:"output_dir"`
+	// FromTimestamp is the lower bound used the first time export-run is
+	// invoked; every run after that exports since the previous run's
+	// start time, tracked in StateFile.
+	FromTimestamp time.Time `mapstructure:"from_timestamp"`
+	// StateFile persists the timestamp of the last successful run across
+	// invocations, since export-run is a one-shot process rather than a
+	// long-running daemon.
+	StateFile string `mapstructure:"state_file"`
+}
+
+// BatchProcessingConfig configures the internal/services.BatchProcessor
+// gmailwatch and the IMAP Fetcher submit incoming mail to instead of
+// calling EmailService.ProcessEmail directly, when enabled. Disabled by
+// default: incoming mail is processed inline, the original behavior.
+type BatchProcessingConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	Workers        int           `mapstructure:"workers"`
+	QueueSize      int           `mapstructure:"queue_size"`
+	MaxConcurrency int           `mapstructure:"max_concurrency"`
+	MaxAttempts    int           `mapstructure:"max_attempts"`
+	BaseBackoff    time.Duration `mapstructure:"base_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+}
+
+// IncomingConfig configures the IMAP Fetcher that polls a mailbox for
+// replies, parses them, and hands them to the same EmailService the
+// HTTP/gRPC handlers use. The subsystem is disabled when Host is empty.
+type IncomingConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	// TLSMode selects how the IMAP connection is secured: "implicit"
+	// (default), "starttls", or "none".
+	TLSMode string `mapstructure:"tls_mode"`
+	// Mailbox is the mailbox Fetcher selects before every poll, e.g.
+	// "INBOX".
+	Mailbox string `mapstructure:"mailbox"`
+	// PollInterval is how often Fetcher checks for unseen messages.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	// AccountID is stamped onto every Email this Fetcher produces.
+	AccountID string `mapstructure:"account_id"`
+}
+
+// BodyCacheConfig configures the on-disk cache of raw message/attachment
+// bodies internal/cache.Store maintains alongside EmailService, so a
+// hot message's raw body doesn't require re-fetching/re-rendering it on
+// every GetRawBody/GetAttachmentBody call.
+type BodyCacheConfig struct {
+	// BaseDir is the root directory cached blobs are stored under. The
+	// cache is disabled when this is empty.
+	BaseDir string `mapstructure:"base_dir"`
+	// MaxBytes caps the cache's total on-disk size; 0 means unbounded
+	// (subject only to MinFreeBytes).
+	MaxBytes int64 `mapstructure:"max_bytes"`
+	// MinFreeBytes is the minimum free space BaseDir's volume must retain.
+	MinFreeBytes int64 `mapstructure:"min_free_bytes"`
+}
+
+// BreakerConfig configures the per-provider circuit breakers built by
+// internal/breaker.Registry, so an outage in one downstream can't trip
+// traffic to an unrelated one.
+type BreakerConfig struct {
+	Gmail    ProviderBreakerConfig `mapstructure:"gmail"`
+	Outlook  ProviderBreakerConfig `mapstructure:"outlook"`
+	Database ProviderBreakerConfig `mapstructure:"database"`
+}
+
+// ProviderBreakerConfig tunes a single provider's breaker and its
+// TCP-slow-start-style recovery: once the breaker closes again after a
+// trip, the next SlowStartRequests successful calls use SlowStartPageSize
+// instead of the caller's configured page size/concurrency limit.
+type ProviderBreakerConfig struct {
+	MaxRequests         uint32        `mapstructure:"max_requests"`
+	Timeout             time.Duration `mapstructure:"timeout"`
+	ConsecutiveFailures uint32        `mapstructure:"consecutive_failures"`
+	SlowStartRequests   int           `mapstructure:"slow_start_requests"`
+	SlowStartPageSize   int           `mapstructure:"slow_start_page_size"`
+}
+
+// DefaultProviderBreakerConfig returns the package default thresholds, for
+// callers that build a breaker.Registry outside of a fully loaded Config
+// (e.g. a provider client constructed directly in a test or a one-off tool).
+func DefaultProviderBreakerConfig() ProviderBreakerConfig {
+	return ProviderBreakerConfig{
+		MaxRequests:         DefaultBreakerMaxRequests,
+		Timeout:             DefaultBreakerTimeout,
+		ConsecutiveFailures: DefaultBreakerConsecutiveFailures,
+		SlowStartRequests:   DefaultBreakerSlowStartRequests,
+		SlowStartPageSize:   DefaultBreakerSlowStartPageSize,
+	}
+}
+
+// RateLimitConfig tunes EmailHandler's per-visitor rate limiting, where a
+// "visitor" is keyed by the X-Account-ID header (falling back to client
+// IP). Read and send endpoints are limited separately since a send
+// triggers outbound delivery and should be throttled more strictly; a
+// global limiter additionally bounds total throughput regardless of how
+// many distinct visitors are active.
+type RateLimitConfig struct {
+	ReadRatePerSecond float64 `mapstructure:"read_rate_per_second"`
+	ReadBurst         int     `mapstructure:"read_burst"`
+
+	SendRatePerSecond float64 `mapstructure:"send_rate_per_second"`
+	SendBurst         int     `mapstructure:"send_burst"`
+
+	GlobalRatePerSecond float64 `mapstructure:"global_rate_per_second"`
+	GlobalBurst         int     `mapstructure:"global_burst"`
+
+	// VisitorTTL is how long an idle visitor's bucket is kept before the
+	// background reaper evicts it.
+	VisitorTTL      time.Duration `mapstructure:"visitor_ttl"`
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
 }
 
 // SecurityConfig holds enhanced security settings for the service
@@ -43,7 +216,18 @@ type SecurityConfig struct {
 	EncryptionKey           string   `mapstructure:"encryption_key"`
 	AllowedOrigins         []string `mapstructure:"allowed_origins"`
 	StrictTransportSecurity bool     `mapstructure:"strict_transport_security"`
-	SecretManagerProvider   string   `mapstructure:"secret_manager_provider"`
+
+	// SecretManagerProvider selects the backend that secret://path#key
+	// references are resolved through: "env" (default), "vault", "aws", or
+	// "gcp". Any of EncryptionKey, Database.Password, Gmail.ClientSecret,
+	// and Outlook.ClientSecret may be written as such a reference.
+	SecretManagerProvider string `mapstructure:"secret_manager_provider"`
+	SecretCacheTTL        time.Duration `mapstructure:"secret_cache_ttl"`
+	SecretRefreshInterval time.Duration `mapstructure:"secret_refresh_interval"`
+
+	Vault      secrets.VaultConfig `mapstructure:"vault"`
+	AWSSecrets secrets.AWSConfig   `mapstructure:"aws_secrets"`
+	GCPSecrets secrets.GCPConfig   `mapstructure:"gcp_secrets"`
 }
 
 // DatabaseConfig holds database connection settings
@@ -65,6 +249,10 @@ type GmailConfig struct {
 	PubSubTopic        string        `mapstructure:"pubsub_topic"`
 	PubSubSubscription string        `mapstructure:"pubsub_subscription"`
 	WatchExpiryDuration time.Duration `mapstructure:"watch_expiry_duration"`
+
+	// PubSubAckDeadline is how long the gmailwatch subscriber has to process
+	// a notification before Pub/Sub redelivers it.
+	PubSubAckDeadline time.Duration `mapstructure:"pubsub_ack_deadline"`
 }
 
 // OutlookConfig holds Microsoft Graph API configuration
@@ -81,10 +269,98 @@ type MetricsConfig struct {
 	Enabled     bool   `mapstructure:"enabled"`
 	ServiceName string `mapstructure:"service_name"`
 	Endpoint    string `mapstructure:"endpoint"`
+
+	// DBMetricsPort binds the secondary /db_metrics endpoint, isolating the
+	// datastore collector's per-shard SQL scrapes from the hot-path /metrics
+	// endpoint. Defaults to Port+3 when unset.
+	DBMetricsPort int `mapstructure:"db_metrics_port"`
+
+	// DBScrapeTimeout bounds how long the datastore collector waits on a
+	// single shard's scrape query before treating it as a failure.
+	DBScrapeTimeout time.Duration `mapstructure:"db_scrape_timeout"`
+}
+
+// ShardConfig describes the set of database shards emails are partitioned
+// across, keyed by a stable shard ID.
+type ShardConfig struct {
+	Shards []ShardEndpoint `mapstructure:"shards"`
+}
+
+// ShardEndpoint identifies a single database shard's connection target.
+type ShardEndpoint struct {
+	ShardID          int    `mapstructure:"shard_id"`
+	DataSourceName   string `mapstructure:"dsn"`
+}
+
+// WorkerPoolConfig tunes the size and batching behavior of a repository's
+// bulk-write worker pool.
+type WorkerPoolConfig struct {
+	WorkerCount   int `mapstructure:"worker_count"`
+	QueueCapacity int `mapstructure:"queue_capacity"`
+	BatchSize     int `mapstructure:"batch_size"`
+}
+
+// MailConfig configures the outbound mail dispatcher: which transport
+// delivers queued emails and how aggressively it may send.
+type MailConfig struct {
+	Transport     string        `mapstructure:"transport"` // smtp|ses|sendgrid
+	WorkerCount   int           `mapstructure:"worker_count"`
+	BatchSize     int           `mapstructure:"batch_size"`
+	RatePerSecond float64       `mapstructure:"rate_per_second"`
+	RateBurst     int           `mapstructure:"rate_burst"`
+	MaxRetries    int           `mapstructure:"max_retries"`
+	RetryBackoff  time.Duration `mapstructure:"retry_backoff"`
+
+	// ReplyDomain and ReplySecret, when both set, make EmailService stamp
+	// a signed reply+<token>@ReplyDomain Reply-To header onto every email
+	// it dispatches through its courier. IncomingConfig's Fetcher verifies
+	// the same token, signed with the same secret, when a reply comes
+	// back in. Reply addressing is disabled when either is empty.
+	ReplyDomain string `mapstructure:"reply_domain"`
+	ReplySecret string `mapstructure:"reply_secret"`
+
+	SMTP     SMTPConfig     `mapstructure:"smtp"`
+	SES      SESConfig      `mapstructure:"ses"`
+	SendGrid SendGridConfig `mapstructure:"sendgrid"`
+}
+
+// SMTPConfig holds connection settings for the SMTP transport.
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+// SESConfig holds settings for the Amazon SES transport.
+type SESConfig struct {
+	Region          string `mapstructure:"region"`
+	ConfigurationSet string `mapstructure:"configuration_set"`
+}
+
+// SendGridConfig holds settings for the SendGrid transport.
+type SendGridConfig struct {
+	APIKey string `mapstructure:"api_key"`
 }
 
 // LoadConfig loads and validates configuration from multiple sources with secure credential handling
 func LoadConfig(configPath string, environment string) (*Config, error) {
+	v := newViper(configPath, environment)
+
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("error reading config file: %w", err)
+		}
+	}
+
+	return unmarshalAndValidate(v, environment)
+}
+
+// newViper builds the *viper.Viper used by both LoadConfig and
+// ConfigManager, with every default and source registered but before
+// ReadInConfig is called, so ConfigManager can also use it with
+// WatchConfig.
+func newViper(configPath string, environment string) *viper.Viper {
 	v := viper.New()
 
 	// Set secure defaults
@@ -93,6 +369,33 @@ func LoadConfig(configPath string, environment string) (*Config, error) {
 	v.SetDefault("request_timeout", DefaultRequestTimeout)
 	v.SetDefault("shutdown_timeout", DefaultShutdownTimeout)
 	v.SetDefault("gmail.watch_expiry_duration", DefaultWatchExpiryDuration)
+	v.SetDefault("gmail.pubsub_ack_deadline", DefaultPubSubAckDeadline)
+	v.SetDefault("metrics.db_scrape_timeout", DefaultDBScrapeTimeout)
+	v.SetDefault("mail.transport", "smtp")
+	v.SetDefault("mail.worker_count", DefaultMailWorkerCount)
+	v.SetDefault("mail.batch_size", DefaultMailBatchSize)
+	v.SetDefault("mail.rate_per_second", DefaultMailRatePerSecond)
+	v.SetDefault("mail.rate_burst", DefaultMailRateBurst)
+	v.SetDefault("mail.max_retries", DefaultMailMaxRetries)
+	v.SetDefault("mail.retry_backoff", DefaultMailRetryBackoff)
+	v.SetDefault("security.secret_manager_provider", secrets.ProviderEnv)
+	v.SetDefault("security.secret_cache_ttl", DefaultSecretCacheTTL)
+	v.SetDefault("security.secret_refresh_interval", DefaultSecretRefreshInterval)
+	v.SetDefault("rate_limit.read_rate_per_second", DefaultReadRatePerSecond)
+	v.SetDefault("rate_limit.read_burst", DefaultReadBurst)
+	v.SetDefault("rate_limit.send_rate_per_second", DefaultSendRatePerSecond)
+	v.SetDefault("rate_limit.send_burst", DefaultSendBurst)
+	v.SetDefault("rate_limit.global_rate_per_second", DefaultGlobalRatePerSecond)
+	v.SetDefault("rate_limit.global_burst", DefaultGlobalBurst)
+	v.SetDefault("rate_limit.visitor_ttl", DefaultVisitorTTL)
+	v.SetDefault("rate_limit.cleanup_interval", DefaultVisitorCleanupInterval)
+	for _, provider := range []string{"gmail", "outlook", "database"} {
+		v.SetDefault("breakers."+provider+".max_requests", DefaultBreakerMaxRequests)
+		v.SetDefault("breakers."+provider+".timeout", DefaultBreakerTimeout)
+		v.SetDefault("breakers."+provider+".consecutive_failures", DefaultBreakerConsecutiveFailures)
+		v.SetDefault("breakers."+provider+".slow_start_requests", DefaultBreakerSlowStartRequests)
+		v.SetDefault("breakers."+provider+".slow_start_page_size", DefaultBreakerSlowStartPageSize)
+	}
 
 	// Configure Viper
 	v.SetConfigName(fmt.Sprintf("config.%s", environment))
@@ -104,13 +407,13 @@ func LoadConfig(configPath string, environment string) (*Config, error) {
 	v.AutomaticEnv()
 	v.SetEnvPrefix("EMAIL_SERVICE")
 
-	// Load configuration file
-	if err := v.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return nil, fmt.Errorf("error reading config file: %w", err)
-		}
-	}
+	return v
+}
 
+// unmarshalAndValidate unmarshals v's current state into a Config,
+// resolves secret references, and validates it. It's shared by LoadConfig
+// and every ConfigManager reload so both paths apply identical rules.
+func unmarshalAndValidate(v *viper.Viper, environment string) (*Config, error) {
 	// Load secure credentials from environment
 	loadSecureCredentials(v)
 
@@ -122,6 +425,13 @@ func LoadConfig(configPath string, environment string) (*Config, error) {
 	// Set environment
 	config.Environment = environment
 
+	// Resolve any secret://path#key references (database password, Gmail
+	// and Outlook client secrets, encryption key) through the configured
+	// secret backend before validating, so Validate sees real values.
+	if err := resolveSecretReferences(&config); err != nil {
+		return nil, fmt.Errorf("failed to resolve secret references: %w", err)
+	}
+
 	// Validate configuration
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
@@ -130,6 +440,47 @@ func LoadConfig(configPath string, environment string) (*Config, error) {
 	return &config, nil
 }
 
+// resolveSecretReferences builds a secret Resolver for the configured
+// backend and resolves every field that may hold a "secret://path#key"
+// reference in place. Constructing the backend fails fast if it's
+// unreachable (e.g. Vault AppRole login fails), rather than surfacing a
+// confusing error on first use. It also starts a background goroutine
+// that periodically re-fetches the secrets held in the Resolver's cache.
+// That refresh does not update the values already written into cfg: a
+// rotated secret reaches a Config only when the config is loaded again.
+func resolveSecretReferences(cfg *Config) error {
+	provider, err := secrets.NewProvider(cfg.Security.SecretManagerProvider, secrets.ProviderConfig{
+		Vault: cfg.Security.Vault,
+		AWS:   cfg.Security.AWSSecrets,
+		GCP:   cfg.Security.GCPSecrets,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize secret provider: %w", err)
+	}
+
+	cache := secrets.NewCache(provider, cfg.Security.SecretCacheTTL)
+	resolver := secrets.NewResolver(cache)
+
+	fields := []*string{
+		&cfg.Database.Password,
+		&cfg.Gmail.ClientSecret,
+		&cfg.Outlook.ClientSecret,
+		&cfg.Security.EncryptionKey,
+	}
+	ctx := context.Background()
+	for _, field := range fields {
+		resolved, err := resolver.Resolve(ctx, *field)
+		if err != nil {
+			return err
+		}
+		*field = resolved
+	}
+
+	go cache.StartPeriodicRefresh(ctx, cfg.Security.SecretRefreshInterval)
+
+	return nil
+}
+
 // loadSecureCredentials loads sensitive credentials from environment variables
 func loadSecureCredentials(v *viper.Viper) {
 	// Database credentials
@@ -200,6 +551,73 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("security config validation failed: %w", err)
 	}
 
+	// Validate rate limit configuration
+	if err := c.validateRateLimitConfig(); err != nil {
+		return fmt.Errorf("rate limit config validation failed: %w", err)
+	}
+
+	// Validate circuit breaker configuration
+	if err := c.validateBreakerConfig(); err != nil {
+		return fmt.Errorf("breaker config validation failed: %w", err)
+	}
+
+	return nil
+}
+
+// validateBreakerConfig validates every provider's circuit breaker and
+// slow-start thresholds.
+func (c *Config) validateBreakerConfig() error {
+	for name, pb := range map[string]ProviderBreakerConfig{
+		"gmail":    c.Breakers.Gmail,
+		"outlook":  c.Breakers.Outlook,
+		"database": c.Breakers.Database,
+	} {
+		if pb.MaxRequests == 0 {
+			return fmt.Errorf("breakers.%s.max_requests must be positive", name)
+		}
+		if pb.Timeout < time.Second {
+			return fmt.Errorf("breakers.%s.timeout must be at least 1 second", name)
+		}
+		if pb.ConsecutiveFailures == 0 {
+			return fmt.Errorf("breakers.%s.consecutive_failures must be positive", name)
+		}
+		if pb.SlowStartRequests < 0 {
+			return fmt.Errorf("breakers.%s.slow_start_requests must not be negative", name)
+		}
+		if pb.SlowStartPageSize < 0 {
+			return fmt.Errorf("breakers.%s.slow_start_page_size must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// validateRateLimitConfig validates the per-visitor rate limit configuration
+func (c *Config) validateRateLimitConfig() error {
+	rl := c.RateLimit
+	if rl.ReadRatePerSecond <= 0 {
+		return fmt.Errorf("rate_limit.read_rate_per_second must be positive")
+	}
+	if rl.ReadBurst <= 0 {
+		return fmt.Errorf("rate_limit.read_burst must be positive")
+	}
+	if rl.SendRatePerSecond <= 0 {
+		return fmt.Errorf("rate_limit.send_rate_per_second must be positive")
+	}
+	if rl.SendBurst <= 0 {
+		return fmt.Errorf("rate_limit.send_burst must be positive")
+	}
+	if rl.GlobalRatePerSecond <= 0 {
+		return fmt.Errorf("rate_limit.global_rate_per_second must be positive")
+	}
+	if rl.GlobalBurst <= 0 {
+		return fmt.Errorf("rate_limit.global_burst must be positive")
+	}
+	if rl.VisitorTTL <= 0 {
+		return fmt.Errorf("rate_limit.visitor_ttl must be positive")
+	}
+	if rl.CleanupInterval <= 0 {
+		return fmt.Errorf("rate_limit.cleanup_interval must be positive")
+	}
 	return nil
 }
 
@@ -242,6 +660,9 @@ func (c *Config) validateGmailConfig() error {
 	if gmail.WatchExpiryDuration < time.Hour {
 		return fmt.Errorf("gmail watch expiry duration must be at least 1 hour")
 	}
+	if gmail.PubSubAckDeadline <= 0 {
+		return fmt.Errorf("gmail pubsub ack deadline must be positive")
+	}
 	return nil
 }
 
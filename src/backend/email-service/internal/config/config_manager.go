@@ -0,0 +1,126 @@
+package config
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify" // v1.7.0
+	"github.com/prometheus/client_golang/prometheus" // v1.16.0
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/spf13/viper" // v1.17.0
+)
+
+var (
+	configReloadsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "config_manager_reloads_total",
+		Help: "Total number of config file changes successfully applied",
+	})
+
+	configReloadFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "config_manager_reload_failures_total",
+		Help: "Total number of config file changes rejected by validation; the prior config is kept",
+	})
+)
+
+// subscription pairs a section extractor with the callback to invoke when
+// that section changes. section is only used to detect change via
+// reflect.DeepEqual; the callback itself always receives the full old and
+// new Config so it can read whatever fields it needs.
+type subscription struct {
+	section func(c *Config) interface{}
+	fn      func(old, new *Config)
+}
+
+// ConfigManager owns a live *viper.Viper and the most recently validated
+// Config, swapped atomically on each reload. Consumers that need to react
+// to configuration changes (handlers, the email service, rate limiters,
+// circuit breakers) register a section-scoped OnChange callback instead of
+// re-reading configuration on every request.
+type ConfigManager struct {
+	v           *viper.Viper
+	environment string
+
+	current atomic.Pointer[Config]
+
+	mu          sync.Mutex
+	subscribers []subscription
+}
+
+// NewConfigManager loads the initial configuration the same way LoadConfig
+// does, then starts watching the resolved config file for changes via
+// viper's fsnotify-based WatchConfig. Call Current to read the live
+// config and OnChange to subscribe to a section of it.
+func NewConfigManager(configPath string, environment string) (*ConfigManager, error) {
+	v := newViper(configPath, environment)
+
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, err
+		}
+	}
+
+	cfg, err := unmarshalAndValidate(v, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := &ConfigManager{v: v, environment: environment}
+	cm.current.Store(cfg)
+
+	v.OnConfigChange(func(_ fsnotify.Event) {
+		cm.reload()
+	})
+	v.WatchConfig()
+
+	return cm, nil
+}
+
+// Current returns the most recently validated Config. Safe for concurrent
+// use; the returned value should be treated as immutable since it may be
+// shared with other callers and swapped out from under a long-lived
+// reference at any time.
+func (cm *ConfigManager) Current() *Config {
+	return cm.current.Load()
+}
+
+// OnChange registers fn to be called after a successful reload, but only
+// when section(old) and section(new) differ (by reflect.DeepEqual). For
+// example, a rate limiter that only cares about RateLimit would register:
+//
+//	cm.OnChange(func(c *config.Config) interface{} { return c.RateLimit }, func(old, new *config.Config) {
+//	    limiter.SetLimit(rate.Limit(new.RateLimit.GlobalRatePerSecond))
+//	})
+func (cm *ConfigManager) OnChange(section func(c *Config) interface{}, fn func(old, new *Config)) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	cm.subscribers = append(cm.subscribers, subscription{section: section, fn: fn})
+}
+
+// reload re-unmarshals and validates the current viper state. If
+// validation fails, the prior Config is kept and a failure counter is
+// incremented rather than serving a known-bad configuration. On success,
+// the Config is atomically swapped and every subscriber whose section
+// actually changed is notified.
+func (cm *ConfigManager) reload() {
+	newCfg, err := unmarshalAndValidate(cm.v, cm.environment)
+	if err != nil {
+		configReloadFailuresTotal.Inc()
+		return
+	}
+
+	oldCfg := cm.current.Swap(newCfg)
+	configReloadsTotal.Inc()
+
+	cm.mu.Lock()
+	subs := make([]subscription, len(cm.subscribers))
+	copy(subs, cm.subscribers)
+	cm.mu.Unlock()
+
+	for _, sub := range subs {
+		if reflect.DeepEqual(sub.section(oldCfg), sub.section(newCfg)) {
+			continue
+		}
+		sub.fn(oldCfg, newCfg)
+	}
+}
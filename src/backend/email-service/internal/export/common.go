@@ -0,0 +1,111 @@
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// chunkEmails splits emails into batches of at most size, preserving
+// order, so an Exporter never has to hold more than one batch's worth of
+// bodies/attachments in memory at a time.
+func chunkEmails(emails []models.Email, size int) [][]models.Email {
+	if size <= 0 {
+		size = defaultBatchSize
+	}
+
+	var chunks [][]models.Email
+	for start := 0; start < len(emails); start += size {
+		end := start + size
+		if end > len(emails) {
+			end = len(emails)
+		}
+		chunks = append(chunks, emails[start:end])
+	}
+	return chunks
+}
+
+// emailDir returns the directory an email's files (body and attachments)
+// are grouped under inside an export archive, rooted at its thread so a
+// reviewer can tell which messages belong to the same conversation.
+func emailDir(e *models.Email) string {
+	thread := e.ThreadID
+	if thread == "" {
+		thread = "unthreaded"
+	}
+	return path.Join(sanitizeFilename(thread), sanitizeFilename(e.MessageID))
+}
+
+// sanitizeFilename strips path separators and leading dots from an
+// untrusted identifier (MessageID, ThreadID, Attachment.Filename) so it
+// can't be used to traverse outside an export archive's intended
+// directory structure.
+func sanitizeFilename(name string) string {
+	name = strings.ReplaceAll(name, "/", "_")
+	name = strings.ReplaceAll(name, "\\", "_")
+	name = strings.TrimLeft(name, ".")
+	if name == "" {
+		name = "unnamed"
+	}
+	return name
+}
+
+// renderRFC822 builds a minimal RFC822 message from an Email's structured
+// fields. It's a compliance-export rendering rather than the email's
+// original wire bytes: Email doesn't retain those once parsed, and
+// fetching the original from the cache/primary store would tie every
+// Exporter to that subsystem for no benefit to an .eml/CSV/HTML export.
+func renderRFC822(e *models.Email) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Message-ID: <%s>\r\n", e.MessageID)
+	fmt.Fprintf(&b, "From: %s\r\n", e.FromAddress)
+	if len(e.ToAddresses) > 0 {
+		fmt.Fprintf(&b, "To: %s\r\n", strings.Join(e.ToAddresses, ", "))
+	}
+	if len(e.CCAddresses) > 0 {
+		fmt.Fprintf(&b, "Cc: %s\r\n", strings.Join(e.CCAddresses, ", "))
+	}
+	fmt.Fprintf(&b, "Subject: %s\r\n", e.Subject)
+	if !e.SentAt.IsZero() {
+		fmt.Fprintf(&b, "Date: %s\r\n", e.SentAt.UTC().Format(time.RFC1123Z))
+	}
+	for k, v := range e.Headers {
+		fmt.Fprintf(&b, "%s: %s\r\n", k, v)
+	}
+	b.WriteString("\r\n")
+	b.WriteString(e.Content)
+
+	return []byte(b.String())
+}
+
+// writeAttachmentsToZip adds each of e's attachments to zw under dir,
+// fetching its body from store. A fetch failure is logged into the
+// archive as a placeholder entry rather than aborting the export, since
+// one missing attachment shouldn't sink an otherwise-complete compliance
+// bundle.
+func writeAttachmentsToZip(ctx context.Context, zw *zip.Writer, dir string, e *models.Email, store AttachmentStore) error {
+	for _, att := range e.Attachments {
+		name := path.Join(dir, sanitizeFilename(att.Filename))
+
+		body, err := store.FetchAttachment(ctx, att.StoragePath)
+		if err != nil {
+			body = []byte(fmt.Sprintf("failed to fetch attachment %s: %v", att.AttachmentID, err))
+			name += ".fetch-error.txt"
+		}
+
+		w, err := zw.Create(name)
+		if err != nil {
+			return fmt.Errorf("export: failed to add %s to archive: %w", name, err)
+		}
+		if _, err := w.Write(body); err != nil {
+			return fmt.Errorf("export: failed to write %s: %w", name, err)
+		}
+	}
+	return nil
+}
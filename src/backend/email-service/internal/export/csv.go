@@ -0,0 +1,88 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// csvHeader lists the columns CSVExporter writes, in order.
+var csvHeader = []string{
+	"message_id", "thread_id", "account_id", "from", "to", "cc",
+	"subject", "sent_at", "received_at", "attachment_count", "attachment_filenames",
+}
+
+// CSVExporter writes a single CSV manifest row per email, summarizing its
+// metadata and attachment list without embedding attachment bodies.
+type CSVExporter struct {
+	batchSize int
+}
+
+// NewCSVExporter creates a CSVExporter. batchSize bounds how many emails
+// are held in memory per write pass; 0 uses defaultBatchSize.
+func NewCSVExporter(batchSize int) *CSVExporter {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &CSVExporter{batchSize: batchSize}
+}
+
+// Write serializes emails into a single CSV manifest with one header row
+// followed by one row per email.
+func (x *CSVExporter) Write(ctx context.Context, emails []models.Email, w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("export: failed to write csv header: %w", err)
+	}
+
+	for _, chunk := range chunkEmails(emails, x.batchSize) {
+		for i := range chunk {
+			if err := cw.Write(csvRow(&chunk[i])); err != nil {
+				return fmt.Errorf("export: failed to write csv row for %s: %w", chunk[i].MessageID, err)
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return fmt.Errorf("export: failed to flush csv writer: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// csvRow renders e as a single CSV row matching csvHeader's column order.
+func csvRow(e *models.Email) []string {
+	filenames := make([]string, len(e.Attachments))
+	for i, att := range e.Attachments {
+		filenames[i] = att.Filename
+	}
+
+	var sentAt, receivedAt string
+	if !e.SentAt.IsZero() {
+		sentAt = e.SentAt.UTC().Format(time.RFC3339)
+	}
+	if !e.ReceivedAt.IsZero() {
+		receivedAt = e.ReceivedAt.UTC().Format(time.RFC3339)
+	}
+
+	return []string{
+		e.MessageID,
+		e.ThreadID,
+		e.AccountID,
+		e.FromAddress,
+		strings.Join(e.ToAddresses, ";"),
+		strings.Join(e.CCAddresses, ";"),
+		e.Subject,
+		sentAt,
+		receivedAt,
+		strconv.Itoa(len(e.Attachments)),
+		strings.Join(filenames, ";"),
+	}
+}
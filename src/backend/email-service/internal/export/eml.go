@@ -0,0 +1,61 @@
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// EMLExporter writes each email as a raw .eml file, grouped by thread,
+// inside a single zip archive.
+type EMLExporter struct {
+	store     AttachmentStore
+	batchSize int
+}
+
+// NewEMLExporter creates an EMLExporter that resolves attachment bodies
+// through store. batchSize bounds how many emails are rendered at once;
+// 0 uses defaultBatchSize.
+func NewEMLExporter(store AttachmentStore, batchSize int) (*EMLExporter, error) {
+	if store == nil {
+		return nil, fmt.Errorf("export: attachment store is required")
+	}
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &EMLExporter{store: store, batchSize: batchSize}, nil
+}
+
+// Write serializes emails into a zip archive of .eml files, one per
+// email, alongside each email's attachments.
+func (x *EMLExporter) Write(ctx context.Context, emails []models.Email, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	for _, chunk := range chunkEmails(emails, x.batchSize) {
+		for i := range chunk {
+			e := &chunk[i]
+			dir := emailDir(e)
+
+			msgWriter, err := zw.Create(path.Join(dir, "message.eml"))
+			if err != nil {
+				return fmt.Errorf("export: failed to add message for %s: %w", e.MessageID, err)
+			}
+			if _, err := msgWriter.Write(renderRFC822(e)); err != nil {
+				return fmt.Errorf("export: failed to write message for %s: %w", e.MessageID, err)
+			}
+
+			if err := writeAttachmentsToZip(ctx, zw, dir, e, x.store); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("export: failed to finalize archive: %w", err)
+	}
+	return nil
+}
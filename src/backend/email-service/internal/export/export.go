@@ -0,0 +1,31 @@
+// Package export serializes batches of models.Email into compliance
+// archive formats (raw .eml, a CSV manifest, or a GlobalRelay-style
+// per-thread HTML bundle) for retention/e-discovery handoff, plus a daily
+// scheduler that drives a recurring export of everything sent or received
+// since the last run.
+package export
+
+import (
+	"context"
+	"io"
+
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// defaultBatchSize bounds how many emails an Exporter holds in memory at
+// once; Write processes its input in chunks of this size unless the
+// exporter overrides it.
+const defaultBatchSize = 100
+
+// Exporter serializes a slice of emails (with attachments) to w in a
+// specific compliance format.
+type Exporter interface {
+	Write(ctx context.Context, emails []models.Email, w io.Writer) error
+}
+
+// AttachmentStore resolves an attachment's StoragePath to its raw bytes,
+// so exporters work the same whether attachments live on local disk, in
+// the cache package's blob store, or in S3.
+type AttachmentStore interface {
+	FetchAttachment(ctx context.Context, storagePath string) ([]byte, error)
+}
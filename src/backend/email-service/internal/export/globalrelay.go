@@ -0,0 +1,225 @@
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// globalRelayTemplateSource renders one HTML document per thread, in the
+// style of GlobalRelay's per-conversation export: a participant list
+// followed by each message's metadata and body, with attachments linked
+// as separate files alongside the document.
+const globalRelayTemplateSource = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Thread {{.ThreadID}}</title></head>
+<body>
+<h1>Thread {{.ThreadID}}</h1>
+<h2>Participants</h2>
+<ul>
+{{range .Participants}}<li>{{.}}</li>
+{{end}}</ul>
+<h2>Messages</h2>
+{{range .Messages}}<div class="message">
+<h3>{{.Subject}}</h3>
+<p><strong>From:</strong> {{.From}}</p>
+<p><strong>To:</strong> {{.To}}</p>
+<p><strong>Sent:</strong> {{.SentAt}}</p>
+<pre>{{.Body}}</pre>
+{{if .Attachments}}<p><strong>Attachments:</strong></p>
+<ul>
+{{range .Attachments}}<li><a href="{{.}}">{{.}}</a></li>
+{{end}}</ul>
+{{end}}</div>
+{{end}}
+</body>
+</html>
+`
+
+var globalRelayTemplate = template.Must(template.New("thread").Parse(globalRelayTemplateSource))
+
+// threadView is the data a thread's HTML document is rendered from.
+type threadView struct {
+	ThreadID     string
+	Participants []string
+	Messages     []messageView
+}
+
+// messageView is a single message's rendering within a threadView.
+type messageView struct {
+	Subject     string
+	From        string
+	To          string
+	SentAt      string
+	Body        string
+	Attachments []string
+}
+
+// GlobalRelayExporter writes one HTML document per thread, bundled with
+// its attachments, into a single zip archive.
+type GlobalRelayExporter struct {
+	store     AttachmentStore
+	batchSize int
+}
+
+// NewGlobalRelayExporter creates a GlobalRelayExporter that resolves
+// attachment bodies through store. batchSize bounds how many threads are
+// rendered at once; 0 uses defaultBatchSize.
+func NewGlobalRelayExporter(store AttachmentStore, batchSize int) (*GlobalRelayExporter, error) {
+	if store == nil {
+		return nil, fmt.Errorf("export: attachment store is required")
+	}
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &GlobalRelayExporter{store: store, batchSize: batchSize}, nil
+}
+
+// Write serializes emails as one HTML document per thread. Threads are
+// grouped across the full input before any chunking, so a single
+// thread's messages can never be split across two archive entries; only
+// the resulting thread keys are chunked, to bound how many threads are
+// rendered into memory at once.
+func (x *GlobalRelayExporter) Write(ctx context.Context, emails []models.Email, w io.Writer) error {
+	grouped := groupByThread(emails)
+	keys := sortedThreadKeys(grouped)
+
+	zw := zip.NewWriter(w)
+
+	for _, batch := range chunkThreadKeys(keys, x.batchSize) {
+		for _, threadID := range batch {
+			if err := writeThread(ctx, zw, threadID, grouped[threadID], x.store); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("export: failed to finalize archive: %w", err)
+	}
+	return nil
+}
+
+// groupByThread groups emails by ThreadID. Emails without a ThreadID are
+// each treated as a single-message thread keyed by their own MessageID.
+func groupByThread(emails []models.Email) map[string][]models.Email {
+	grouped := make(map[string][]models.Email)
+	for _, e := range emails {
+		key := e.ThreadID
+		if key == "" {
+			key = e.MessageID
+		}
+		grouped[key] = append(grouped[key], e)
+	}
+	return grouped
+}
+
+// sortedThreadKeys returns grouped's keys in a deterministic order, so
+// repeated exports of the same input produce the same archive.
+func sortedThreadKeys(grouped map[string][]models.Email) []string {
+	keys := make([]string, 0, len(grouped))
+	for k := range grouped {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// chunkThreadKeys splits keys into batches of at most size.
+func chunkThreadKeys(keys []string, size int) [][]string {
+	if size <= 0 {
+		size = defaultBatchSize
+	}
+
+	var chunks [][]string
+	for start := 0; start < len(keys); start += size {
+		end := start + size
+		if end > len(keys) {
+			end = len(keys)
+		}
+		chunks = append(chunks, keys[start:end])
+	}
+	return chunks
+}
+
+// writeThread renders threadID's messages into "<threadID>/thread.html"
+// plus "<threadID>/attachments/<filename>" entries in zw.
+func writeThread(ctx context.Context, zw *zip.Writer, threadID string, msgs []models.Email, store AttachmentStore) error {
+	sorted := make([]models.Email, len(msgs))
+	copy(sorted, msgs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].SentAt.Before(sorted[j].SentAt) })
+
+	dir := sanitizeFilename(threadID)
+
+	view := threadView{
+		ThreadID:     threadID,
+		Participants: threadParticipants(sorted),
+	}
+	for i := range sorted {
+		e := &sorted[i]
+
+		var sentAt string
+		if !e.SentAt.IsZero() {
+			sentAt = e.SentAt.UTC().Format(time.RFC1123Z)
+		}
+
+		var attachments []string
+		for _, att := range e.Attachments {
+			attachments = append(attachments, path.Join("attachments", sanitizeFilename(att.Filename)))
+		}
+
+		view.Messages = append(view.Messages, messageView{
+			Subject:     e.Subject,
+			From:        e.FromAddress,
+			To:          strings.Join(e.ToAddresses, ", "),
+			SentAt:      sentAt,
+			Body:        e.Content,
+			Attachments: attachments,
+		})
+
+		if err := writeAttachmentsToZip(ctx, zw, path.Join(dir, "attachments"), e, store); err != nil {
+			return err
+		}
+	}
+
+	docWriter, err := zw.Create(path.Join(dir, "thread.html"))
+	if err != nil {
+		return fmt.Errorf("export: failed to add thread document for %s: %w", threadID, err)
+	}
+	if err := globalRelayTemplate.Execute(docWriter, view); err != nil {
+		return fmt.Errorf("export: failed to render thread document for %s: %w", threadID, err)
+	}
+	return nil
+}
+
+// threadParticipants returns the sorted, de-duplicated set of addresses
+// (From/To/CC) across every message in a thread.
+func threadParticipants(msgs []models.Email) []string {
+	seen := make(map[string]struct{})
+	for _, e := range msgs {
+		seen[e.FromAddress] = struct{}{}
+		for _, addr := range e.ToAddresses {
+			seen[addr] = struct{}{}
+		}
+		for _, addr := range e.CCAddresses {
+			seen[addr] = struct{}{}
+		}
+	}
+
+	participants := make([]string, 0, len(seen))
+	for addr := range seen {
+		if addr != "" {
+			participants = append(participants, addr)
+		}
+	}
+	sort.Strings(participants)
+	return participants
+}
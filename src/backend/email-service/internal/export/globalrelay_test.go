@@ -0,0 +1,124 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// fakeAttachmentStore returns a fixed body for every attachment, enough
+// for tests that only care about the resulting archive structure.
+type fakeAttachmentStore struct{}
+
+func (fakeAttachmentStore) FetchAttachment(ctx context.Context, storagePath string) ([]byte, error) {
+	return []byte("attachment body"), nil
+}
+
+func sentAt(hour, minute int) time.Time {
+	return time.Date(2026, 1, 5, hour, minute, 0, 0, time.UTC)
+}
+
+// TestGlobalRelayExporterMatchesGoldenFile verifies a thread's rendered
+// HTML document byte-for-byte against a committed fixture, so a change
+// to globalRelayTemplateSource is caught even if it still parses.
+func TestGlobalRelayExporterMatchesGoldenFile(t *testing.T) {
+	emails := []models.Email{
+		{
+			MessageID:   "msg-1",
+			ThreadID:    "thread-abc",
+			Subject:     "Project Update",
+			Content:     "Here is the update.",
+			FromAddress: "alice@example.com",
+			ToAddresses: []string{"bob@example.com"},
+			SentAt:      sentAt(10, 0),
+		},
+		{
+			MessageID:   "msg-2",
+			ThreadID:    "thread-abc",
+			Subject:     "Re: Project Update",
+			Content:     "Thanks, looks good.",
+			FromAddress: "bob@example.com",
+			ToAddresses: []string{"alice@example.com"},
+			SentAt:      sentAt(11, 30),
+			Attachments: []models.Attachment{
+				{AttachmentID: "att-1", Filename: "notes.txt", ContentType: "text/plain", StoragePath: "s3://bucket/notes.txt"},
+			},
+		},
+	}
+
+	exporter, err := NewGlobalRelayExporter(fakeAttachmentStore{}, 0)
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := exporter.Write(context.Background(), emails, &buf); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to read generated archive: %v", err)
+	}
+
+	f, err := zr.Open("thread-abc/thread.html")
+	if err != nil {
+		t.Fatalf("archive missing thread-abc/thread.html: %v", err)
+	}
+	defer f.Close()
+
+	got, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("failed to read thread document: %v", err)
+	}
+
+	want, err := os.ReadFile("testdata/thread.golden.html")
+	if err != nil {
+		t.Fatalf("failed to read golden fixture: %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Fatalf("thread document does not match golden fixture\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+}
+
+// TestGlobalRelayExporterKeepsThreadsTogetherAcrossBatches verifies a
+// thread whose messages would fall into different batches under a naive
+// chunk-then-group strategy still ends up as a single archive entry.
+func TestGlobalRelayExporterKeepsThreadsTogetherAcrossBatches(t *testing.T) {
+	emails := []models.Email{
+		{MessageID: "msg-1", ThreadID: "thread-x", Subject: "One", FromAddress: "a@example.com", SentAt: sentAt(9, 0)},
+		{MessageID: "msg-2", ThreadID: "thread-x", Subject: "Two", FromAddress: "a@example.com", SentAt: sentAt(9, 5)},
+	}
+
+	exporter, err := NewGlobalRelayExporter(fakeAttachmentStore{}, 1)
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := exporter.Write(context.Background(), emails, &buf); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to read generated archive: %v", err)
+	}
+
+	var threadDocs int
+	for _, f := range zr.File {
+		if f.Name == "thread-x/thread.html" {
+			threadDocs++
+		}
+	}
+	if threadDocs != 1 {
+		t.Fatalf("expected exactly one thread-x/thread.html entry, got %d", threadDocs)
+	}
+}
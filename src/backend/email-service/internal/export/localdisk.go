@@ -0,0 +1,54 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// LocalDiskSink writes each export archive to a file named for the run
+// under BaseDir, for deployments without a retention bucket configured yet.
+type LocalDiskSink struct {
+	BaseDir string
+}
+
+// NewLocalDiskSink creates a LocalDiskSink rooted at baseDir, creating it if
+// it doesn't already exist.
+func NewLocalDiskSink(baseDir string) (*LocalDiskSink, error) {
+	if err := os.MkdirAll(baseDir, 0o750); err != nil {
+		return nil, fmt.Errorf("export: failed to create output directory %q: %w", baseDir, err)
+	}
+	return &LocalDiskSink{BaseDir: baseDir}, nil
+}
+
+// WriteExport writes body to BaseDir/name.
+func (s *LocalDiskSink) WriteExport(ctx context.Context, name string, body io.Reader) error {
+	path := filepath.Join(s.BaseDir, name)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("export: failed to create %q: %w", path, err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, body); err != nil {
+		return fmt.Errorf("export: failed to write %q: %w", path, err)
+	}
+	return nil
+}
+
+// LocalDiskAttachmentStore resolves an attachment's StoragePath by reading
+// it directly off local disk, the same place attachments are written to
+// when an email is received.
+type LocalDiskAttachmentStore struct{}
+
+// FetchAttachment reads storagePath from local disk.
+func (LocalDiskAttachmentStore) FetchAttachment(ctx context.Context, storagePath string) ([]byte, error) {
+	body, err := os.ReadFile(storagePath)
+	if err != nil {
+		return nil, fmt.Errorf("export: failed to read attachment %q: %w", storagePath, err)
+	}
+	return body, nil
+}
@@ -0,0 +1,18 @@
+package export
+
+import (
+	"github.com/prometheus/client_golang/prometheus" // v1.17.0
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	exportRunsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "compliance_export_runs_total",
+		Help: "Total number of DailyRunner export runs by outcome",
+	}, []string{"result"})
+
+	exportEmailsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "compliance_export_emails_total",
+		Help: "Total number of emails written across all compliance export runs",
+	})
+)
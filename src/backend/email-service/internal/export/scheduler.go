@@ -0,0 +1,138 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// EmailSource supplies the emails a DailyRunner exports, everything sent
+// or received since a given timestamp.
+type EmailSource interface {
+	EmailsSince(ctx context.Context, since time.Time) ([]models.Email, error)
+}
+
+// OutputSink receives a completed export archive, named for the run it
+// came from (e.g. to upload it to the configured retention bucket).
+type OutputSink interface {
+	WriteExport(ctx context.Context, name string, body io.Reader) error
+}
+
+// SchedulerConfig controls when DailyRunner runs and how far back its
+// first run looks.
+type SchedulerConfig struct {
+	// DailyRunTime is the time of day (in the local time zone) each
+	// export runs, e.g. time.Date(0, 1, 1, 2, 0, 0, 0, time.UTC) for
+	// 2:00 AM. Only the hour/minute/second fields are used.
+	DailyRunTime time.Time
+	// ExportFromTimestamp is the lower bound used for the very first
+	// run; every run after that exports since the previous run's start
+	// time.
+	ExportFromTimestamp time.Time
+}
+
+// DailyRunner drives a single Exporter once a day, exporting every email
+// sent or received since the last run.
+type DailyRunner struct {
+	cfg      SchedulerConfig
+	source   EmailSource
+	exporter Exporter
+	sink     OutputSink
+	logger   *slog.Logger
+
+	lastRun time.Time
+}
+
+// NewDailyRunner creates a DailyRunner that exports source's emails with
+// exporter and hands the resulting archive to sink once a day at
+// cfg.DailyRunTime. logger may be nil, in which case slog.Default() is
+// used.
+func NewDailyRunner(cfg SchedulerConfig, source EmailSource, exporter Exporter, sink OutputSink, logger *slog.Logger) (*DailyRunner, error) {
+	if source == nil {
+		return nil, fmt.Errorf("export: email source is required")
+	}
+	if exporter == nil {
+		return nil, fmt.Errorf("export: exporter is required")
+	}
+	if sink == nil {
+		return nil, fmt.Errorf("export: output sink is required")
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &DailyRunner{
+		cfg:      cfg,
+		source:   source,
+		exporter: exporter,
+		sink:     sink,
+		logger:   logger,
+		lastRun:  cfg.ExportFromTimestamp,
+	}, nil
+}
+
+// Start blocks, running RunOnce once a day at cfg.DailyRunTime, until ctx
+// is cancelled.
+func (r *DailyRunner) Start(ctx context.Context) error {
+	for {
+		wait := r.durationUntilNextRun(time.Now())
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+
+		if err := r.RunOnce(ctx); err != nil {
+			r.logger.Error("export: daily run failed", "error", err)
+		}
+	}
+}
+
+// RunOnce exports every email since the last successful run (or
+// cfg.ExportFromTimestamp, for the first run) and hands the archive to
+// sink, advancing the last-run timestamp only once both steps succeed.
+func (r *DailyRunner) RunOnce(ctx context.Context) error {
+	since := r.lastRun
+	runStart := time.Now()
+
+	emails, err := r.source.EmailsSince(ctx, since)
+	if err != nil {
+		exportRunsTotal.WithLabelValues("error").Inc()
+		return fmt.Errorf("export: failed to load emails since %s: %w", since, err)
+	}
+
+	var buf bytes.Buffer
+	if err := r.exporter.Write(ctx, emails, &buf); err != nil {
+		exportRunsTotal.WithLabelValues("error").Inc()
+		return fmt.Errorf("export: failed to write export archive: %w", err)
+	}
+
+	name := fmt.Sprintf("export-%s.zip", runStart.UTC().Format("20060102-150405"))
+	if err := r.sink.WriteExport(ctx, name, &buf); err != nil {
+		exportRunsTotal.WithLabelValues("error").Inc()
+		return fmt.Errorf("export: failed to deliver export archive %s: %w", name, err)
+	}
+
+	exportRunsTotal.WithLabelValues("success").Inc()
+	exportEmailsTotal.Add(float64(len(emails)))
+	r.lastRun = runStart
+	return nil
+}
+
+// durationUntilNextRun returns how long to wait from now until the next
+// occurrence of cfg.DailyRunTime's hour/minute/second.
+func (r *DailyRunner) durationUntilNextRun(now time.Time) time.Duration {
+	next := time.Date(now.Year(), now.Month(), now.Day(),
+		r.cfg.DailyRunTime.Hour(), r.cfg.DailyRunTime.Minute(), r.cfg.DailyRunTime.Second(), 0,
+		now.Location())
+	if !next.After(now) {
+		next = next.Add(24 * time.Hour)
+	}
+	return next.Sub(now)
+}
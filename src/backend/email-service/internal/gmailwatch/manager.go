@@ -0,0 +1,317 @@
+// Package gmailwatch drives Gmail's users.watch/Pub/Sub push flow so the
+// email service learns about mailbox changes as they happen instead of
+// polling SyncChanges on a timer. It calls users.watch for each configured
+// mailbox, persists the resulting watch's expiry so it survives a restart,
+// renews each watch at half its lifetime, and subscribes to the configured
+// Pub/Sub subscription to turn incoming historyId notifications into calls
+// to SyncChanges and the email service.
+package gmailwatch
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors" // v0.9.1
+
+	"github.com/email-management-platform/backend/email-service/internal/config"
+	"github.com/email-management-platform/backend/email-service/internal/models"
+	"github.com/email-management-platform/backend/email-service/internal/repositories"
+	"github.com/email-management-platform/backend/email-service/pkg/pushsync"
+)
+
+// MailboxClient is the subset of pkg/gmail.GmailClient the gmailwatch
+// subsystem depends on.
+type MailboxClient interface {
+	Watch(ctx context.Context, opts pushsync.WatchOptions) (*pushsync.Subscription, error)
+	Unwatch(ctx context.Context, sub *pushsync.Subscription) error
+	SyncChanges(ctx context.Context, cursor string) ([]*models.EmailChange, string, error)
+	GetEmail(ctx context.Context, messageID string) (*models.Email, error)
+}
+
+// EmailProcessor is the subset of services.EmailService the gmailwatch
+// subsystem depends on to hand resolved changes off for processing.
+type EmailProcessor interface {
+	ProcessEmail(ctx context.Context, email *models.Email) error
+}
+
+// EmailNotifier is an optional interface a processor may additionally
+// implement (e.g. handlers.EmailHandler) to learn about successfully
+// processed changes, such as to fan them out to gRPC WatchEmails streams.
+// It is checked with a type assertion rather than folded into
+// EmailProcessor so processors that don't care about live streaming (like a
+// bare services.EmailService) aren't forced to implement it.
+type EmailNotifier interface {
+	NotifyEmail(email *models.Email)
+}
+
+// Subscriber receives decoded Gmail Pub/Sub notifications and dispatches
+// them to handle. Receive blocks until ctx is cancelled or a
+// non-recoverable error occurs. See NewPubSubSubscriber for the
+// cloud.google.com/go/pubsub-backed implementation used in production.
+type Subscriber interface {
+	Receive(ctx context.Context, handle func(ctx context.Context, accountID, historyID string)) error
+	Stop() error
+}
+
+// mailbox is one watched Gmail account's client and last-synced cursor.
+type mailbox struct {
+	accountID string
+	client    MailboxClient
+
+	mu     sync.Mutex
+	cursor string
+}
+
+// Manager runs the watch-renewal and notification-handling loops for every
+// configured mailbox.
+type Manager struct {
+	cfg        config.GmailConfig
+	store      *repositories.GmailWatchRepository
+	processor  EmailProcessor
+	subscriber Subscriber
+	logger     *slog.Logger
+
+	mailboxes map[string]*mailbox
+
+	mu               sync.Mutex
+	subscriberCancel context.CancelFunc
+	renewalCancel    context.CancelFunc
+}
+
+// NewManager creates a Manager for the given mailboxes (accountID ->
+// MailboxClient). logger may be nil, in which case slog.Default() is used.
+func NewManager(cfg config.GmailConfig, store *repositories.GmailWatchRepository, processor EmailProcessor, subscriber Subscriber, clients map[string]MailboxClient, logger *slog.Logger) (*Manager, error) {
+	if store == nil {
+		return nil, errors.New("gmail watch store is required")
+	}
+	if processor == nil {
+		return nil, errors.New("email processor is required")
+	}
+	if subscriber == nil {
+		return nil, errors.New("pub/sub subscriber is required")
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	mailboxes := make(map[string]*mailbox, len(clients))
+	for accountID, client := range clients {
+		mailboxes[accountID] = &mailbox{accountID: accountID, client: client}
+	}
+
+	return &Manager{
+		cfg:        cfg,
+		store:      store,
+		processor:  processor,
+		subscriber: subscriber,
+		logger:     logger,
+		mailboxes:  mailboxes,
+	}, nil
+}
+
+// Start establishes a watch for every configured mailbox, begins each
+// mailbox's renewal ticker, and starts the Pub/Sub subscriber. It returns
+// once every mailbox's initial watch attempt has completed; a mailbox
+// whose initial watch fails is logged and skipped rather than failing
+// startup for every other mailbox.
+func (m *Manager) Start(ctx context.Context) error {
+	renewalCtx, renewalCancel := context.WithCancel(ctx)
+	subCtx, subCancel := context.WithCancel(ctx)
+
+	m.mu.Lock()
+	m.renewalCancel = renewalCancel
+	m.subscriberCancel = subCancel
+	m.mu.Unlock()
+
+	for _, mb := range m.mailboxes {
+		if err := m.establishWatch(ctx, mb); err != nil {
+			m.logger.Error("gmailwatch: initial watch failed", "account_id", mb.accountID, "error", err)
+			continue
+		}
+		go m.renewalLoop(renewalCtx, mb)
+	}
+
+	go func() {
+		if err := m.subscriber.Receive(subCtx, m.handleNotification); err != nil && subCtx.Err() == nil {
+			m.logger.Error("gmailwatch: subscriber stopped unexpectedly", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop halts the subsystem. On a rolling restart (decommission=false) it
+// simply cancels the renewal loops and the subscriber together, leaving
+// every mailbox's Gmail watch registration intact so it's still valid the
+// next time this process (or its replacement) starts. On a decommission
+// (decommission=true) it stops the subscriber first, then explicitly
+// unwatches every mailbox so Gmail stops publishing notifications nobody
+// will be running to consume.
+func (m *Manager) Stop(ctx context.Context, decommission bool) error {
+	m.mu.Lock()
+	subCancel, renewalCancel := m.subscriberCancel, m.renewalCancel
+	m.mu.Unlock()
+
+	if !decommission {
+		if subCancel != nil {
+			subCancel()
+		}
+		if renewalCancel != nil {
+			renewalCancel()
+		}
+		return nil
+	}
+
+	if subCancel != nil {
+		subCancel()
+	}
+	if err := m.subscriber.Stop(); err != nil {
+		m.logger.Warn("gmailwatch: error stopping subscriber", "error", err)
+	}
+
+	var unwatchErrs []error
+	for accountID, mb := range m.mailboxes {
+		watch, err := m.store.Get(ctx, accountID)
+		if err != nil {
+			unwatchErrs = append(unwatchErrs, err)
+			continue
+		}
+		if watch == nil {
+			continue
+		}
+		if err := mb.client.Unwatch(ctx, &pushsync.Subscription{
+			Provider:  pushsync.ProviderGmail,
+			HistoryID: watch.HistoryID,
+		}); err != nil {
+			unwatchErrs = append(unwatchErrs, err)
+			continue
+		}
+		activeWatches.Dec()
+		watchExpirySeconds.DeleteLabelValues(accountID)
+	}
+
+	if renewalCancel != nil {
+		renewalCancel()
+	}
+
+	if len(unwatchErrs) > 0 {
+		return errors.Errorf("gmailwatch: %d mailbox(es) failed to unwatch during shutdown", len(unwatchErrs))
+	}
+	return nil
+}
+
+// renewalLoop re-invokes watch for mb at half its configured expiry, until
+// ctx is cancelled.
+func (m *Manager) renewalLoop(ctx context.Context, mb *mailbox) {
+	interval := m.cfg.WatchExpiryDuration / 2
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.renewWatch(ctx, mb); err != nil {
+				m.logger.Error("gmailwatch: renewal failed", "account_id", mb.accountID, "error", err)
+			}
+		}
+	}
+}
+
+// establishWatch creates a mailbox's first watch registration and counts
+// it in activeWatches.
+func (m *Manager) establishWatch(ctx context.Context, mb *mailbox) error {
+	if err := m.renewWatch(ctx, mb); err != nil {
+		return err
+	}
+	activeWatches.Inc()
+	return nil
+}
+
+// renewWatch calls users.watch for mb, persists the resulting expiry, and
+// updates mb's cursor and the expiry gauge.
+func (m *Manager) renewWatch(ctx context.Context, mb *mailbox) error {
+	sub, err := mb.client.Watch(ctx, pushsync.WatchOptions{
+		Topic:  m.cfg.PubSubTopic,
+		Expiry: m.cfg.WatchExpiryDuration,
+	})
+	if err != nil {
+		watchRenewalsTotal.WithLabelValues(mb.accountID, "failure").Inc()
+		return err
+	}
+
+	mb.mu.Lock()
+	mb.cursor = sub.HistoryID
+	mb.mu.Unlock()
+
+	if err := m.store.Save(ctx, &repositories.GmailWatch{
+		AccountID: mb.accountID,
+		HistoryID: sub.HistoryID,
+		Topic:     m.cfg.PubSubTopic,
+		ExpiresAt: sub.ExpiresAt,
+	}); err != nil {
+		m.logger.Error("gmailwatch: failed to persist watch", "account_id", mb.accountID, "error", err)
+	}
+
+	watchExpirySeconds.WithLabelValues(mb.accountID).Set(time.Until(sub.ExpiresAt).Seconds())
+	watchRenewalsTotal.WithLabelValues(mb.accountID, "success").Inc()
+	return nil
+}
+
+// handleNotification resolves the changes since the mailbox's last known
+// cursor and hands each non-deleted change's full email to the processor,
+// triggering incremental sync in response to a Pub/Sub push.
+func (m *Manager) handleNotification(ctx context.Context, accountID, _ string) {
+	mb, ok := m.mailboxes[accountID]
+	if !ok {
+		m.logger.Warn("gmailwatch: notification for unwatched account", "account_id", accountID)
+		return
+	}
+
+	mb.mu.Lock()
+	cursor := mb.cursor
+	mb.mu.Unlock()
+
+	changes, newCursor, err := mb.client.SyncChanges(ctx, cursor)
+	if err != nil {
+		notificationsTotal.WithLabelValues(accountID, "error").Inc()
+		m.logger.Error("gmailwatch: sync changes failed", "account_id", accountID, "error", err)
+		return
+	}
+
+	mb.mu.Lock()
+	mb.cursor = newCursor
+	mb.mu.Unlock()
+
+	for _, change := range changes {
+		if change.Change == models.ChangeDeleted {
+			continue
+		}
+
+		email, err := mb.client.GetEmail(ctx, change.MessageID)
+		if err != nil {
+			m.logger.Error("gmailwatch: failed to fetch changed email",
+				"account_id", accountID, "message_id", change.MessageID, "error", err)
+			continue
+		}
+		if email == nil {
+			continue
+		}
+		email.AccountID = accountID
+
+		if err := m.processor.ProcessEmail(ctx, email); err != nil {
+			m.logger.Error("gmailwatch: failed to process changed email",
+				"account_id", accountID, "message_id", change.MessageID, "error", err)
+			continue
+		}
+
+		if notifier, ok := m.processor.(EmailNotifier); ok {
+			notifier.NotifyEmail(email)
+		}
+	}
+
+	notificationsTotal.WithLabelValues(accountID, "success").Inc()
+}
@@ -0,0 +1,28 @@
+package gmailwatch
+
+import (
+	"github.com/prometheus/client_golang/prometheus" // v1.17.0
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	activeWatches = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "gmailwatch_active_watches",
+		Help: "Number of mailboxes with an active Gmail watch registration",
+	})
+
+	watchExpirySeconds = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gmailwatch_watch_expiry_seconds",
+		Help: "Seconds remaining until the account's current watch registration expires",
+	}, []string{"account"})
+
+	watchRenewalsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gmailwatch_renewals_total",
+		Help: "Total number of watch establish/renewal attempts",
+	}, []string{"account", "status"})
+
+	notificationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gmailwatch_notifications_total",
+		Help: "Total number of Pub/Sub notifications processed",
+	}, []string{"account", "status"})
+)
@@ -0,0 +1,91 @@
+package gmailwatch
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/pubsub" // v1.33.0
+	"github.com/pkg/errors" // v0.9.1
+
+	"github.com/email-management-platform/backend/email-service/internal/config"
+)
+
+// gmailPushPayload mirrors the JSON body Gmail publishes to Pub/Sub for a
+// mailbox change, delivered as a pubsub.Message's Data.
+type gmailPushPayload struct {
+	EmailAddress string `json:"emailAddress"`
+	HistoryID    uint64 `json:"historyId"`
+}
+
+// pubSubSubscriber is the production Subscriber, backed by
+// cloud.google.com/go/pubsub.
+type pubSubSubscriber struct {
+	sub *pubsub.Subscription
+}
+
+// NewPubSubSubscriber builds a Subscriber bound to cfg.PubSubSubscription,
+// with its ReceiveSettings ack deadline taken from cfg.PubSubAckDeadline.
+// The GCP project is taken from cfg.PubSubTopic, which the Gmail API
+// requires to already be a fully qualified "projects/{project}/topics/{id}"
+// resource name.
+func NewPubSubSubscriber(ctx context.Context, cfg config.GmailConfig) (*pubSubSubscriber, error) {
+	projectID := projectFromResourceName(cfg.PubSubTopic)
+	if projectID == "" {
+		return nil, errors.Errorf("gmailwatch: gmail.pubsub_topic %q is not a fully qualified projects/{project}/topics/{id} resource name", cfg.PubSubTopic)
+	}
+
+	client, err := pubsub.NewClient(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create pub/sub client")
+	}
+
+	sub := client.Subscription(cfg.PubSubSubscription)
+	sub.ReceiveSettings.MaxExtension = cfg.PubSubAckDeadline
+
+	return &pubSubSubscriber{sub: sub}, nil
+}
+
+// projectFromResourceName extracts the project ID from a fully qualified
+// "projects/{project}/..." resource name, or "" if name isn't in that form.
+func projectFromResourceName(name string) string {
+	const prefix = "projects/"
+	if !strings.HasPrefix(name, prefix) {
+		return ""
+	}
+	rest := name[len(prefix):]
+	if i := strings.Index(rest, "/"); i >= 0 {
+		return rest[:i]
+	}
+	return ""
+}
+
+// Receive blocks dispatching decoded notifications to handle until ctx is
+// cancelled.
+func (s *pubSubSubscriber) Receive(ctx context.Context, handle func(ctx context.Context, accountID, historyID string)) error {
+	return s.sub.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
+		var payload gmailPushPayload
+		if err := json.Unmarshal(msg.Data, &payload); err != nil {
+			msg.Nack()
+			return
+		}
+
+		handle(ctx, payload.EmailAddress, formatHistoryID(payload.HistoryID))
+		msg.Ack()
+	})
+}
+
+// Stop is a no-op: cancelling the context passed to Receive is what
+// actually halts the pull loop, this just satisfies the Subscriber
+// interface for callers that want an explicit stop step.
+func (s *pubSubSubscriber) Stop() error {
+	return nil
+}
+
+func formatHistoryID(id uint64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatUint(id, 10)
+}
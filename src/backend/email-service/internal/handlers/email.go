@@ -11,15 +11,16 @@ import (
     "github.com/gin-gonic/gin" // v1.9.1
     "github.com/prometheus/client_golang/prometheus" // v1.17.0
     "github.com/prometheus/client_golang/prometheus/promauto"
-    "github.com/sony/gobreaker" // v0.5.0
     "github.com/pkg/errors" // v0.9.1
-    "golang.org/x/time/rate" // v0.3.0
     "google.golang.org/grpc" // v1.58.2
     "google.golang.org/grpc/codes"
     "google.golang.org/grpc/status"
 
+    "github.com/email-management-platform/backend/email-service/internal/breaker"
+    "github.com/email-management-platform/backend/email-service/internal/config"
     "github.com/email-management-platform/backend/email-service/internal/models"
     "github.com/email-management-platform/backend/email-service/internal/services"
+    "github.com/email-management-platform/backend/email-service/pkg/emailgrpc"
 )
 
 const (
@@ -48,12 +49,19 @@ var (
     })
 )
 
+// breakerProvider is the provider label EmailHandler's own routes use when
+// keying into breakers, distinguishing them from the downstream "gmail" and
+// "outlook" provider clients that share the same registry type.
+const breakerProvider = "email_handler"
+
 // EmailHandler handles email-related HTTP/gRPC endpoints with reliability features
 type EmailHandler struct {
-    emailService services.EmailService
-    breaker     *gobreaker.CircuitBreaker
-    rateLimiter *rate.Limiter
-    metrics     *handlerMetrics
+    emailService *services.EmailService
+    breakers     *breaker.Registry
+    readLimiter  *visitorLimiter
+    sendLimiter  *visitorLimiter
+    metrics      *handlerMetrics
+    broadcaster  *watchBroadcaster
 }
 
 type handlerMetrics struct {
@@ -62,30 +70,39 @@ type handlerMetrics struct {
     active   prometheus.Gauge
 }
 
-// NewEmailHandler creates a new instance of EmailHandler with required dependencies
-func NewEmailHandler(emailService services.EmailService) (*EmailHandler, error) {
+// NewEmailHandler creates a new instance of EmailHandler with required
+// dependencies. breakers may be nil, in which case a Registry built from
+// config.DefaultProviderBreakerConfig is used.
+func NewEmailHandler(emailService *services.EmailService, rateLimitCfg config.RateLimitConfig, breakers *breaker.Registry) (*EmailHandler, error) {
     if emailService == nil {
         return nil, errors.New("email service is required")
     }
 
-    // Initialize circuit breaker
-    cb := gobreaker.NewCircuitBreaker(gobreaker.Settings{
-        Name:        "email_handler",
-        MaxRequests: uint32(maxPageSize),
-        Timeout:     defaultTimeout,
-        ReadyToTrip: func(counts gobreaker.Counts) bool {
-            failureRatio := float64(counts.TotalFailures) / float64(counts.Requests)
-            return counts.Requests >= 10 && failureRatio >= 0.6
-        },
-    })
+    if breakers == nil {
+        defaults := config.DefaultProviderBreakerConfig()
+        breakers = breaker.NewRegistry(config.BreakerConfig{
+            Gmail:    defaults,
+            Outlook:  defaults,
+            Database: defaults,
+        })
+    }
+
+    // Read endpoints get the looser of the two visitor limiters; send
+    // (POST /emails) triggers outbound delivery and is throttled more
+    // strictly.
+    readLimiter := newVisitorLimiter(rateLimitCfg, rateLimitCfg.ReadRatePerSecond, rateLimitCfg.ReadBurst)
+    sendLimiter := newVisitorLimiter(rateLimitCfg, rateLimitCfg.SendRatePerSecond, rateLimitCfg.SendBurst)
 
-    // Initialize rate limiter
-    limiter := rate.NewLimiter(rate.Limit(100), maxPageSize)
+    reapCtx := context.Background()
+    go readLimiter.reap(reapCtx, rateLimitCfg.CleanupInterval)
+    go sendLimiter.reap(reapCtx, rateLimitCfg.CleanupInterval)
 
     handler := &EmailHandler{
         emailService: emailService,
-        breaker:     cb,
-        rateLimiter: limiter,
+        breakers:     breakers,
+        readLimiter:  readLimiter,
+        sendLimiter:  sendLimiter,
+        broadcaster:  newWatchBroadcaster(),
         metrics: &handlerMetrics{
             duration: requestDuration,
             errors:   requestErrors,
@@ -104,27 +121,34 @@ func (h *EmailHandler) RegisterHTTPRoutes(router *gin.RouterGroup) {
 
     // Add middleware
     router.Use(h.metricsMiddleware())
-    router.Use(h.rateLimitMiddleware())
-    router.Use(h.circuitBreakerMiddleware())
-
-    // Register routes
-    router.GET("/emails/:messageId", h.handleGetEmail)
-    router.GET("/emails", h.handleListEmails)
-    router.POST("/emails", h.handleSendEmail)
-    router.DELETE("/emails/:messageId", h.handleDeleteEmail)
-    router.PUT("/emails/:messageId/labels", h.handleUpdateLabels)
-    router.PUT("/emails/:messageId/folder", h.handleMoveToFolder)
-    router.GET("/threads/:threadId", h.handleGetThread)
+
+    // Register routes. POST /emails uses the stricter send limiter since
+    // it triggers outbound delivery; every other route uses the read
+    // limiter. Each route keys its own breaker so a run of failures on one
+    // operation (e.g. send_email) can't trip routes that don't share its
+    // failure mode.
+    router.GET("/emails/:messageId", h.rateLimitMiddleware(h.readLimiter), h.circuitBreakerMiddleware("get_email"), h.handleGetEmail)
+    router.GET("/emails/:messageId/raw", h.rateLimitMiddleware(h.readLimiter), h.circuitBreakerMiddleware("get_email_raw"), h.handleGetEmailRaw)
+    router.GET("/emails/:messageId/attachments/:attachmentId", h.rateLimitMiddleware(h.readLimiter), h.circuitBreakerMiddleware("get_attachment"), h.handleGetAttachment)
+    router.GET("/emails", h.rateLimitMiddleware(h.readLimiter), h.circuitBreakerMiddleware("list_emails"), h.handleListEmails)
+    router.POST("/emails", h.rateLimitMiddleware(h.sendLimiter), h.circuitBreakerMiddleware("send_email"), h.handleSendEmail)
+    router.DELETE("/emails/:messageId", h.rateLimitMiddleware(h.readLimiter), h.circuitBreakerMiddleware("delete_email"), h.handleDeleteEmail)
+    router.PUT("/emails/:messageId/labels", h.rateLimitMiddleware(h.readLimiter), h.circuitBreakerMiddleware("update_labels"), h.handleUpdateLabels)
+    router.PUT("/emails/:messageId/folder", h.rateLimitMiddleware(h.readLimiter), h.circuitBreakerMiddleware("move_to_folder"), h.handleMoveToFolder)
+    router.GET("/threads/:threadId", h.rateLimitMiddleware(h.readLimiter), h.circuitBreakerMiddleware("get_thread"), h.handleGetThread)
 }
 
-// RegisterGRPCServer registers gRPC server methods with monitoring
+// RegisterGRPCServer registers EmailHandler as the email.v1.EmailService
+// implementation. Its interceptors (metrics, rate limiting, circuit
+// breaking; see UnaryServerInterceptors) must be chained in via
+// grpc.ChainUnaryInterceptor when server was constructed, since grpc.Server
+// only accepts interceptors at construction time.
 func (h *EmailHandler) RegisterGRPCServer(server *grpc.Server) {
     if server == nil {
         return
     }
 
-    // Register gRPC service implementation
-    // Note: Actual implementation would be in a separate protobuf-generated file
+    emailgrpc.RegisterEmailServiceServer(server, h)
 }
 
 // HTTP handler implementations
@@ -163,6 +187,65 @@ func (h *EmailHandler) handleGetEmail(c *gin.Context) {
     c.JSON(http.StatusOK, email)
 }
 
+func (h *EmailHandler) handleGetEmailRaw(c *gin.Context) {
+    timer := prometheus.NewTimer(h.metrics.duration.WithLabelValues("get_email_raw", ""))
+    defer timer.ObserveDuration()
+
+    h.metrics.active.Inc()
+    defer h.metrics.active.Dec()
+
+    messageID := c.Param("messageId")
+    accountID := c.GetHeader("X-Account-ID")
+
+    if messageID == "" || accountID == "" {
+        h.metrics.errors.WithLabelValues("get_email_raw", "invalid_request").Inc()
+        c.JSON(http.StatusBadRequest, gin.H{"error": "missing required parameters"})
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(c.Request.Context(), defaultTimeout)
+    defer cancel()
+
+    raw, err := h.emailService.GetRawBody(ctx, messageID, accountID)
+    if err != nil {
+        h.metrics.errors.WithLabelValues("get_email_raw", "internal_error").Inc()
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get raw email body"})
+        return
+    }
+
+    c.Data(http.StatusOK, "message/rfc822", raw)
+}
+
+func (h *EmailHandler) handleGetAttachment(c *gin.Context) {
+    timer := prometheus.NewTimer(h.metrics.duration.WithLabelValues("get_attachment", ""))
+    defer timer.ObserveDuration()
+
+    h.metrics.active.Inc()
+    defer h.metrics.active.Dec()
+
+    messageID := c.Param("messageId")
+    attachmentID := c.Param("attachmentId")
+    accountID := c.GetHeader("X-Account-ID")
+
+    if messageID == "" || attachmentID == "" || accountID == "" {
+        h.metrics.errors.WithLabelValues("get_attachment", "invalid_request").Inc()
+        c.JSON(http.StatusBadRequest, gin.H{"error": "missing required parameters"})
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(c.Request.Context(), defaultTimeout)
+    defer cancel()
+
+    body, err := h.emailService.GetAttachmentBody(ctx, messageID, accountID, attachmentID)
+    if err != nil {
+        h.metrics.errors.WithLabelValues("get_attachment", "internal_error").Inc()
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get attachment body"})
+        return
+    }
+
+    c.Data(http.StatusOK, "application/octet-stream", body)
+}
+
 func (h *EmailHandler) handleListEmails(c *gin.Context) {
     timer := prometheus.NewTimer(h.metrics.duration.WithLabelValues("list_emails", ""))
     defer timer.ObserveDuration()
@@ -219,9 +302,9 @@ func (h *EmailHandler) metricsMiddleware() gin.HandlerFunc {
     }
 }
 
-func (h *EmailHandler) rateLimitMiddleware() gin.HandlerFunc {
+func (h *EmailHandler) rateLimitMiddleware(limiter *visitorLimiter) gin.HandlerFunc {
     return func(c *gin.Context) {
-        if !h.rateLimiter.Allow() {
+        if !limiter.allow(visitorKey(c)) {
             h.metrics.errors.WithLabelValues(c.Request.Method, "rate_limit").Inc()
             c.JSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
             c.Abort()
@@ -231,9 +314,10 @@ func (h *EmailHandler) rateLimitMiddleware() gin.HandlerFunc {
     }
 }
 
-func (h *EmailHandler) circuitBreakerMiddleware() gin.HandlerFunc {
+func (h *EmailHandler) circuitBreakerMiddleware(operation string) gin.HandlerFunc {
+    key := breaker.Key{Provider: breakerProvider, Operation: operation}
     return func(c *gin.Context) {
-        _, err := h.breaker.Execute(func() (interface{}, error) {
+        _, err := h.breakers.Execute(key, func() (interface{}, error) {
             c.Next()
             if c.Writer.Status() >= 500 {
                 return nil, errors.New("server error")
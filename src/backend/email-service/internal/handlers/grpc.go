@@ -0,0 +1,313 @@
+package handlers
+
+import (
+    "context"
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus" // v1.17.0
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/peer"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/types/known/timestamppb"
+
+    "github.com/email-management-platform/backend/email-service/internal/breaker"
+    "github.com/email-management-platform/backend/email-service/internal/models"
+    "github.com/email-management-platform/backend/email-service/internal/services"
+    "github.com/email-management-platform/backend/email-service/pkg/emailgrpc"
+)
+
+// watchKeepaliveInterval controls how often an idle WatchEmails stream
+// receives a Keepalive event, so clients and intermediate proxies can
+// detect a silently dead connection without waiting for the next real
+// message.
+const watchKeepaliveInterval = 30 * time.Second
+
+// UnaryServerInterceptors returns the gRPC equivalents of EmailHandler's
+// HTTP middleware (metrics, rate limiting, circuit breaking), in the order
+// they should be chained with grpc.ChainUnaryInterceptor. They are exposed
+// rather than applied internally because grpc.NewServer only accepts
+// interceptors at construction time, before an EmailHandler exists to
+// register routes on.
+func (h *EmailHandler) UnaryServerInterceptors() []grpc.UnaryServerInterceptor {
+    return []grpc.UnaryServerInterceptor{
+        h.metricsUnaryInterceptor,
+        h.rateLimitUnaryInterceptor,
+        h.circuitBreakerUnaryInterceptor,
+    }
+}
+
+func (h *EmailHandler) metricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+    timer := prometheus.NewTimer(h.metrics.duration.WithLabelValues(info.FullMethod, ""))
+    defer timer.ObserveDuration()
+
+    h.metrics.active.Inc()
+    defer h.metrics.active.Dec()
+
+    resp, err := handler(ctx, req)
+    if err != nil {
+        h.metrics.errors.WithLabelValues(info.FullMethod, "internal_error").Inc()
+    }
+    return resp, err
+}
+
+func (h *EmailHandler) rateLimitUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+    limiter := h.readLimiter
+    if info.FullMethod == emailgrpc.EmailService_SendEmail_FullMethodName {
+        limiter = h.sendLimiter
+    }
+
+    if !limiter.allow(grpcVisitorKey(ctx)) {
+        h.metrics.errors.WithLabelValues(info.FullMethod, "rate_limit").Inc()
+        return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+    }
+    return handler(ctx, req)
+}
+
+func (h *EmailHandler) circuitBreakerUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+    key := breaker.Key{Provider: breakerProvider, Operation: info.FullMethod}
+    resp, err := h.breakers.Execute(key, func() (interface{}, error) {
+        return handler(ctx, req)
+    })
+    if err != nil {
+        h.metrics.errors.WithLabelValues(info.FullMethod, "circuit_breaker").Inc()
+        return nil, status.Error(codes.Unavailable, "service temporarily unavailable")
+    }
+    return resp, nil
+}
+
+// grpcVisitorKey identifies the caller for per-visitor rate limiting,
+// falling back to the peer address since gRPC requests have no header
+// equivalent to the HTTP handlers' X-Account-ID unless one is set.
+func grpcVisitorKey(ctx context.Context) string {
+    if md, ok := metadata.FromIncomingContext(ctx); ok {
+        if values := md.Get("x-account-id"); len(values) > 0 && values[0] != "" {
+            return values[0]
+        }
+    }
+    if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+        return p.Addr.String()
+    }
+    return "unknown"
+}
+
+// EmailServiceServer implementation
+
+// GetEmail implements emailgrpc.EmailServiceServer.
+func (h *EmailHandler) GetEmail(ctx context.Context, req *emailgrpc.GetEmailRequest) (*emailgrpc.EmailMessage, error) {
+    if req.GetMessageId() == "" || req.GetAccountId() == "" {
+        return nil, status.Error(codes.InvalidArgument, "message_id and account_id are required")
+    }
+
+    email, err := h.emailService.GetEmailByID(ctx, req.GetMessageId(), req.GetAccountId())
+    if err != nil {
+        return nil, status.Error(codes.Internal, "failed to get email")
+    }
+    if email == nil {
+        return nil, status.Error(codes.NotFound, "email not found")
+    }
+
+    return toGRPCEmailMessage(email), nil
+}
+
+// ListEmails implements emailgrpc.EmailServiceServer.
+func (h *EmailHandler) ListEmails(ctx context.Context, req *emailgrpc.ListEmailsRequest) (*emailgrpc.ListEmailsResponse, error) {
+    if req.GetAccountId() == "" {
+        return nil, status.Error(codes.InvalidArgument, "account_id is required")
+    }
+
+    pageSize := int(req.GetPageSize())
+    if pageSize <= 0 {
+        pageSize = defaultPageSize
+    } else if pageSize > maxPageSize {
+        pageSize = maxPageSize
+    }
+
+    page, err := h.emailService.ListEmails(ctx, &services.ListEmailsOptions{
+        AccountID:  req.GetAccountId(),
+        PageSize:   pageSize,
+        PageToken:  req.GetPageToken(),
+        FolderPath: req.GetFolderPath(),
+    })
+    if err != nil {
+        return nil, status.Error(codes.Internal, "failed to list emails")
+    }
+
+    emails := make([]*emailgrpc.EmailMessage, len(page.Emails))
+    for i, email := range page.Emails {
+        emails[i] = toGRPCEmailMessage(email)
+    }
+
+    return &emailgrpc.ListEmailsResponse{Emails: emails, NextPageToken: page.NextPageToken}, nil
+}
+
+// SendEmail implements emailgrpc.EmailServiceServer.
+func (h *EmailHandler) SendEmail(ctx context.Context, req *emailgrpc.SendEmailRequest) (*emailgrpc.SendEmailResponse, error) {
+    outgoing := &models.OutgoingEmail{
+        Subject:      req.GetSubject(),
+        TextBody:     req.GetTextBody(),
+        HTMLBody:     req.GetHtmlBody(),
+        FromAddress:  req.GetFromAddress(),
+        ToAddresses:  req.GetToAddresses(),
+        CCAddresses:  req.GetCcAddresses(),
+        BCCAddresses: req.GetBccAddresses(),
+    }
+
+    messageID, err := h.emailService.SendEmail(ctx, outgoing)
+    if err != nil {
+        return nil, status.Error(codes.Internal, "failed to send email")
+    }
+
+    return &emailgrpc.SendEmailResponse{MessageId: messageID}, nil
+}
+
+// DeleteEmail implements emailgrpc.EmailServiceServer.
+func (h *EmailHandler) DeleteEmail(ctx context.Context, req *emailgrpc.DeleteEmailRequest) (*emailgrpc.DeleteEmailResponse, error) {
+    if err := h.emailService.DeleteEmail(ctx, req.GetMessageId(), req.GetAccountId()); err != nil {
+        return nil, status.Error(codes.Internal, "failed to delete email")
+    }
+    return &emailgrpc.DeleteEmailResponse{}, nil
+}
+
+// UpdateLabels implements emailgrpc.EmailServiceServer.
+func (h *EmailHandler) UpdateLabels(ctx context.Context, req *emailgrpc.UpdateLabelsRequest) (*emailgrpc.UpdateLabelsResponse, error) {
+    if err := h.emailService.UpdateLabels(ctx, req.GetMessageId(), req.GetAccountId(), req.GetAddLabels(), req.GetRemoveLabels()); err != nil {
+        return nil, status.Error(codes.Internal, "failed to update labels")
+    }
+    return &emailgrpc.UpdateLabelsResponse{}, nil
+}
+
+// MoveToFolder implements emailgrpc.EmailServiceServer.
+func (h *EmailHandler) MoveToFolder(ctx context.Context, req *emailgrpc.MoveToFolderRequest) (*emailgrpc.MoveToFolderResponse, error) {
+    if err := h.emailService.MoveToFolder(ctx, req.GetMessageId(), req.GetAccountId(), req.GetFolderPath()); err != nil {
+        return nil, status.Error(codes.Internal, "failed to move email")
+    }
+    return &emailgrpc.MoveToFolderResponse{}, nil
+}
+
+// GetThread implements emailgrpc.EmailServiceServer.
+func (h *EmailHandler) GetThread(ctx context.Context, req *emailgrpc.GetThreadRequest) (*emailgrpc.ThreadResponse, error) {
+    thread, err := h.emailService.GetThread(ctx, req.GetThreadId(), req.GetAccountId())
+    if err != nil {
+        return nil, status.Error(codes.Internal, "failed to get thread")
+    }
+
+    emails := make([]*emailgrpc.EmailMessage, len(thread))
+    for i, email := range thread {
+        emails[i] = toGRPCEmailMessage(email)
+    }
+
+    return &emailgrpc.ThreadResponse{Emails: emails}, nil
+}
+
+// WatchEmails implements emailgrpc.EmailServiceServer, streaming every
+// email NotifyEmail learns about for req.AccountId until the client
+// disconnects, interleaved with keepalives so a silently dead connection
+// doesn't go unnoticed.
+func (h *EmailHandler) WatchEmails(req *emailgrpc.WatchEmailsRequest, stream emailgrpc.EmailService_WatchEmailsServer) error {
+    ctx := stream.Context()
+    sub := h.broadcaster.subscribe(req.GetAccountId())
+    defer h.broadcaster.unsubscribe(req.GetAccountId(), sub)
+
+    ticker := time.NewTicker(watchKeepaliveInterval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return ctx.Err()
+        case email := <-sub:
+            if err := stream.Send(&emailgrpc.EmailEvent{Email: toGRPCEmailMessage(email)}); err != nil {
+                return err
+            }
+        case <-ticker.C:
+            if err := stream.Send(&emailgrpc.EmailEvent{Keepalive: true}); err != nil {
+                return err
+            }
+        }
+    }
+}
+
+// NotifyEmail satisfies gmailwatch.EmailNotifier, fanning a successfully
+// processed email out to every WatchEmails subscriber for its account.
+func (h *EmailHandler) NotifyEmail(email *models.Email) {
+    h.broadcaster.publish(email)
+}
+
+func toGRPCEmailMessage(email *models.Email) *emailgrpc.EmailMessage {
+    if email == nil {
+        return nil
+    }
+    return &emailgrpc.EmailMessage{
+        MessageId:      email.MessageID,
+        ThreadId:       email.ThreadID,
+        ConversationId: email.ConversationID,
+        AccountId:      email.AccountID,
+        Subject:        email.Subject,
+        Content:        email.Content,
+        FromAddress:    email.FromAddress,
+        ToAddresses:    email.ToAddresses,
+        CcAddresses:    email.CCAddresses,
+        BccAddresses:   email.BCCAddresses,
+        Labels:         email.Labels,
+        FolderPath:     email.FolderPath,
+        SentAt:         timestamppb.New(email.SentAt),
+        ReceivedAt:     timestamppb.New(email.ReceivedAt),
+    }
+}
+
+// watchBroadcaster fans processed emails out to every active WatchEmails
+// subscriber for the relevant account. Subscribers get a small buffer so a
+// momentarily slow stream goroutine doesn't block NotifyEmail; a
+// subscriber that's still behind when the buffer fills just misses the
+// notification rather than stalling mailbox processing.
+type watchBroadcaster struct {
+    mu          sync.Mutex
+    subscribers map[string][]chan *models.Email
+}
+
+func newWatchBroadcaster() *watchBroadcaster {
+    return &watchBroadcaster{subscribers: make(map[string][]chan *models.Email)}
+}
+
+func (b *watchBroadcaster) subscribe(accountID string) chan *models.Email {
+    ch := make(chan *models.Email, 16)
+
+    b.mu.Lock()
+    b.subscribers[accountID] = append(b.subscribers[accountID], ch)
+    b.mu.Unlock()
+
+    return ch
+}
+
+func (b *watchBroadcaster) unsubscribe(accountID string, ch chan *models.Email) {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+
+    subs := b.subscribers[accountID]
+    for i, sub := range subs {
+        if sub == ch {
+            b.subscribers[accountID] = append(subs[:i], subs[i+1:]...)
+            break
+        }
+    }
+}
+
+func (b *watchBroadcaster) publish(email *models.Email) {
+    if email == nil {
+        return
+    }
+
+    b.mu.Lock()
+    subs := append([]chan *models.Email(nil), b.subscribers[email.AccountID]...)
+    b.mu.Unlock()
+
+    for _, sub := range subs {
+        select {
+        case sub <- email:
+        default:
+        }
+    }
+}
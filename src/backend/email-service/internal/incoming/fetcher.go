@@ -0,0 +1,258 @@
+// Package incoming polls an IMAP/IMAPS mailbox for unread mail, parses
+// each message's MIME body into a models.Email, resolves the thread it
+// belongs to (via In-Reply-To/References headers or a signed reply
+// token embedded in the recipient address), and hands the result off to
+// an EmailProcessor.
+package incoming
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// defaultPollInterval is used when Config.PollInterval is zero.
+const defaultPollInterval = time.Minute
+
+// MailboxClient is the subset of an IMAP connection Fetcher depends on.
+// The production implementation is built by DialMailbox; tests can
+// substitute a fake that serves canned messages without a real server.
+type MailboxClient interface {
+	// SelectMailbox opens the named mailbox for subsequent UnseenUIDs and
+	// FetchRFC822 calls.
+	SelectMailbox(ctx context.Context, name string) error
+	// UnseenUIDs returns the UIDs of every message in the selected
+	// mailbox that isn't marked \Seen.
+	UnseenUIDs(ctx context.Context) ([]uint32, error)
+	// FetchRFC822 returns the full raw RFC 822 message for uid.
+	FetchRFC822(ctx context.Context, uid uint32) ([]byte, error)
+	// Close logs out and releases the underlying connection.
+	Close() error
+}
+
+// EmailProcessor is the subset of services.EmailService the incoming
+// subsystem depends on to hand parsed messages off for processing.
+type EmailProcessor interface {
+	ProcessEmail(ctx context.Context, email *models.Email) error
+}
+
+// ThreadResolver locates the thread an incoming message belongs to from
+// the Message-IDs referenced by its In-Reply-To/References headers, or
+// from a signed reply token embedded in its recipient address. found is
+// false when neither matches a known thread, in which case the message
+// starts a new thread of its own.
+type ThreadResolver interface {
+	ResolveThread(ctx context.Context, messageIDRefs []string, replyToken string) (threadID, conversationID string, nextPosition int32, found bool)
+}
+
+// Config controls how a Fetcher polls a single already-connected mailbox.
+type Config struct {
+	// Mailbox is the name Fetcher selects before every poll, e.g. "INBOX".
+	Mailbox string
+	// PollInterval is how often Fetcher checks for unseen messages.
+	// Defaults to defaultPollInterval if zero.
+	PollInterval time.Duration
+	// AccountID is stamped onto every Email this Fetcher produces.
+	AccountID string
+	// ReplyDomain restricts reply token extraction to recipient
+	// addresses at this domain, e.g. "reply.example.com". If empty, any
+	// domain's reply+<token>@ address is considered.
+	ReplyDomain string
+	// ReplySecret verifies a reply token extracted from a recipient
+	// address via models.VerifyReplyToken before it's trusted to resolve
+	// a thread. A token that fails verification, or was issued for a
+	// different account, is treated the same as no token at all.
+	ReplySecret []byte
+}
+
+// Fetcher polls one mailbox on Config.PollInterval, parsing and
+// dispatching every unseen message it finds to an EmailProcessor.
+type Fetcher struct {
+	cfg       Config
+	client    MailboxClient
+	processor EmailProcessor
+	resolver  ThreadResolver
+	logger    *slog.Logger
+
+	seenMu sync.Mutex
+	seen   map[uint32]struct{}
+}
+
+// NewFetcher creates a Fetcher polling client for cfg.Mailbox. resolver
+// may be nil, in which case incoming messages are never threaded.
+// logger may be nil, in which case slog.Default() is used.
+func NewFetcher(cfg Config, client MailboxClient, processor EmailProcessor, resolver ThreadResolver, logger *slog.Logger) (*Fetcher, error) {
+	if client == nil {
+		return nil, fmt.Errorf("incoming: mailbox client is required")
+	}
+	if processor == nil {
+		return nil, fmt.Errorf("incoming: email processor is required")
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &Fetcher{
+		cfg:       cfg,
+		client:    client,
+		processor: processor,
+		resolver:  resolver,
+		logger:    logger,
+		seen:      make(map[uint32]struct{}),
+	}, nil
+}
+
+// Run polls on cfg.PollInterval until ctx is cancelled, at which point it
+// closes the underlying mailbox client and returns ctx.Err().
+func (f *Fetcher) Run(ctx context.Context) error {
+	defer f.client.Close()
+
+	ticker := time.NewTicker(f.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := f.poll(ctx); err != nil {
+			incomingPollErrorsTotal.Inc()
+			f.logger.Error("incoming: poll failed", "mailbox", f.cfg.Mailbox, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll selects cfg.Mailbox, fetches its unseen UIDs, and hands every UID
+// this Fetcher hasn't already processed to handleMessage.
+func (f *Fetcher) poll(ctx context.Context) error {
+	timer := time.Now()
+	defer func() { incomingPollDuration.Observe(time.Since(timer).Seconds()) }()
+
+	if err := f.client.SelectMailbox(ctx, f.cfg.Mailbox); err != nil {
+		return fmt.Errorf("incoming: failed to select mailbox %q: %w", f.cfg.Mailbox, err)
+	}
+
+	uids, err := f.client.UnseenUIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("incoming: failed to list unseen messages: %w", err)
+	}
+
+	for _, uid := range uids {
+		if f.alreadySeen(uid) {
+			continue
+		}
+		f.handleMessage(ctx, uid)
+	}
+	return nil
+}
+
+// alreadySeen reports whether uid has already been handed to
+// handleMessage, marking it seen if not.
+func (f *Fetcher) alreadySeen(uid uint32) bool {
+	f.seenMu.Lock()
+	defer f.seenMu.Unlock()
+
+	if _, ok := f.seen[uid]; ok {
+		return true
+	}
+	f.seen[uid] = struct{}{}
+	return false
+}
+
+// handleMessage fetches, parses, threads, and dispatches a single
+// message, recovering from any panic so one malformed message can't take
+// down the poll loop.
+func (f *Fetcher) handleMessage(ctx context.Context, uid uint32) {
+	defer func() {
+		if r := recover(); r != nil {
+			incomingMessagesTotal.WithLabelValues("panic").Inc()
+			f.logger.Error("incoming: recovered from panic handling message", "uid", uid, "panic", r)
+		}
+	}()
+
+	raw, err := f.client.FetchRFC822(ctx, uid)
+	if err != nil {
+		incomingMessagesTotal.WithLabelValues("fetch_error").Inc()
+		f.logger.Error("incoming: failed to fetch message", "uid", uid, "error", err)
+		return
+	}
+
+	email, err := ParseMessage(raw)
+	if err != nil {
+		incomingMessagesTotal.WithLabelValues("parse_error").Inc()
+		f.logger.Error("incoming: failed to parse message", "uid", uid, "error", err)
+		return
+	}
+	email.AccountID = f.cfg.AccountID
+
+	f.resolveThread(ctx, email)
+
+	if err := f.processor.ProcessEmail(ctx, email); err != nil {
+		incomingMessagesTotal.WithLabelValues("process_error").Inc()
+		f.logger.Error("incoming: failed to process message", "uid", uid, "message_id", email.MessageID, "error", err)
+		return
+	}
+
+	incomingMessagesTotal.WithLabelValues("success").Inc()
+}
+
+// resolveThread stamps email's ThreadID/ConversationID/ThreadPosition
+// when its In-Reply-To/References headers or a reply token in its
+// recipients match a thread the resolver knows about. It's a no-op if no
+// resolver was configured.
+func (f *Fetcher) resolveThread(ctx context.Context, email *models.Email) {
+	if f.resolver == nil {
+		return
+	}
+
+	refs := referencesFromHeaders(email.Headers)
+	token := f.verifiedReplyToken(email)
+	if len(refs) == 0 && token == "" {
+		return
+	}
+
+	threadID, conversationID, nextPosition, found := f.resolver.ResolveThread(ctx, refs, token)
+	if !found {
+		return
+	}
+
+	email.ThreadID = threadID
+	email.ConversationID = conversationID
+	email.ThreadPosition = nextPosition
+}
+
+// verifiedReplyToken extracts a reply+<token>@domain recipient from
+// email.ToAddresses, if any, and returns it only once it's been confirmed
+// genuine: models.VerifyReplyToken must accept its signature and expiry
+// against f.cfg.ReplySecret, and the account it was issued for must match
+// f.cfg.AccountID. Otherwise a forged reply+<anything>@domain address
+// could be used to resolve (and so leak into) a thread it was never
+// actually part of. Returns "" if no token is present or it doesn't
+// verify.
+func (f *Fetcher) verifiedReplyToken(email *models.Email) string {
+	token := extractReplyToken(email.ToAddresses, f.cfg.ReplyDomain)
+	if token == "" {
+		return ""
+	}
+
+	_, accountID, err := models.VerifyReplyToken(token, f.cfg.ReplySecret)
+	if err != nil {
+		f.logger.Warn("incoming: rejected reply token that failed verification", "message_id", email.MessageID, "error", err)
+		return ""
+	}
+	if accountID != f.cfg.AccountID {
+		f.logger.Warn("incoming: rejected reply token issued for a different account", "message_id", email.MessageID, "token_account_id", accountID)
+		return ""
+	}
+
+	return token
+}
@@ -0,0 +1,115 @@
+package incoming
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+
+	"github.com/emersion/go-imap" // v1.2.1
+	"github.com/emersion/go-imap/client" // v1.2.1
+)
+
+// TLSMode selects how DialMailbox secures its connection.
+type TLSMode int
+
+const (
+	// TLSImplicit dials straight into TLS (the imaps:// convention, port
+	// 993).
+	TLSImplicit TLSMode = iota
+	// TLSStartTLS connects in plaintext and upgrades via STARTTLS before
+	// logging in (the imap:// + STARTTLS convention, port 143).
+	TLSStartTLS
+	// TLSNone never encrypts the connection. Only intended for
+	// connecting to a local test server.
+	TLSNone
+)
+
+// IMAPConfig describes how to connect and authenticate to a mailbox.
+type IMAPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	TLSMode  TLSMode
+}
+
+// DialMailbox connects to cfg.Host:cfg.Port, negotiates TLS per
+// cfg.TLSMode, logs in, and returns a MailboxClient ready for
+// SelectMailbox.
+func DialMailbox(cfg IMAPConfig) (MailboxClient, error) {
+	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+
+	var conn *client.Client
+	var err error
+	if cfg.TLSMode == TLSImplicit {
+		conn, err = client.DialTLS(addr, &tls.Config{ServerName: cfg.Host})
+	} else {
+		conn, err = client.Dial(addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("incoming: failed to connect to %s: %w", addr, err)
+	}
+
+	if cfg.TLSMode == TLSStartTLS {
+		if err := conn.StartTLS(&tls.Config{ServerName: cfg.Host}); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("incoming: starttls failed: %w", err)
+		}
+	}
+
+	if err := conn.Login(cfg.Username, cfg.Password); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("incoming: login failed: %w", err)
+	}
+
+	return &imapMailboxClient{conn: conn}, nil
+}
+
+// imapMailboxClient is the production MailboxClient, backed by
+// github.com/emersion/go-imap.
+type imapMailboxClient struct {
+	conn *client.Client
+}
+
+func (c *imapMailboxClient) SelectMailbox(ctx context.Context, name string) error {
+	_, err := c.conn.Select(name, false)
+	return err
+}
+
+func (c *imapMailboxClient) UnseenUIDs(ctx context.Context) ([]uint32, error) {
+	criteria := imap.NewSearchCriteria()
+	criteria.WithoutFlags = []string{imap.SeenFlag}
+	return c.conn.UidSearch(criteria)
+}
+
+func (c *imapMailboxClient) FetchRFC822(ctx context.Context, uid uint32) ([]byte, error) {
+	seqSet := new(imap.SeqSet)
+	seqSet.AddNum(uid)
+
+	section := &imap.BodySectionName{}
+	messages := make(chan *imap.Message, 1)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- c.conn.UidFetch(seqSet, []imap.FetchItem{section.FetchItem()}, messages)
+	}()
+
+	msg, ok := <-messages
+	if err := <-done; err != nil {
+		return nil, fmt.Errorf("incoming: uid fetch %d failed: %w", uid, err)
+	}
+	if !ok || msg == nil {
+		return nil, fmt.Errorf("incoming: message with uid %d not found", uid)
+	}
+
+	body := msg.GetBody(section)
+	if body == nil {
+		return nil, fmt.Errorf("incoming: message with uid %d has no body", uid)
+	}
+	return io.ReadAll(body)
+}
+
+func (c *imapMailboxClient) Close() error {
+	return c.conn.Logout()
+}
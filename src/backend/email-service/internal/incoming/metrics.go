@@ -0,0 +1,23 @@
+package incoming
+
+import (
+	"github.com/prometheus/client_golang/prometheus" // v1.17.0
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	incomingMessagesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "incoming_messages_total",
+		Help: "Total number of fetched messages by outcome",
+	}, []string{"status"})
+
+	incomingPollDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name: "incoming_poll_duration_seconds",
+		Help: "Duration of a single mailbox poll, from select through the last fetched message",
+	})
+
+	incomingPollErrorsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "incoming_poll_errors_total",
+		Help: "Total number of poll cycles that failed to select the mailbox or list unseen messages",
+	})
+)
@@ -0,0 +1,196 @@
+package incoming
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// ParseMessage parses a full raw RFC 822 message, as fetched from IMAP,
+// into an Email. multipart/alternative and multipart/mixed bodies are
+// walked recursively: the first text/plain part found becomes
+// Email.Content (falling back to the first text/html part if there's no
+// text/plain alternative). Every part with a filename, an attachment
+// disposition or a non-text media type becomes an Attachment with its
+// SHA-256 Checksum; any other text part is dropped.
+func ParseMessage(raw []byte) (*models.Email, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("incoming: failed to parse message: %w", err)
+	}
+
+	email := &models.Email{
+		Headers:    make(map[string]string, len(msg.Header)),
+		ReceivedAt: time.Now(),
+	}
+	for key := range msg.Header {
+		email.Headers[key] = msg.Header.Get(key)
+	}
+
+	email.Subject = msg.Header.Get("Subject")
+	if from, err := mail.ParseAddress(msg.Header.Get("From")); err == nil {
+		email.FromAddress = from.Address
+	}
+	email.ToAddresses = parseAddressList(msg.Header.Get("To"))
+	email.CCAddresses = parseAddressList(msg.Header.Get("Cc"))
+
+	if sentAt, err := msg.Header.Date(); err == nil {
+		email.SentAt = sentAt
+	}
+	if messageID := strings.Trim(msg.Header.Get("Message-Id"), "<>"); messageID != "" {
+		email.MessageID = messageID
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		body, err := io.ReadAll(msg.Body)
+		if err != nil {
+			return nil, fmt.Errorf("incoming: failed to read message body: %w", err)
+		}
+		email.Content = string(body)
+		return email, nil
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if err := parseMultipart(email, msg.Body, params["boundary"]); err != nil {
+			return nil, err
+		}
+		return email, nil
+	}
+
+	body, err := decodeBody(msg.Body, msg.Header.Get("Content-Transfer-Encoding"))
+	if err != nil {
+		return nil, fmt.Errorf("incoming: failed to decode message body: %w", err)
+	}
+	email.Content = string(body)
+	return email, nil
+}
+
+// parseAddressList parses an address-list header, silently dropping it if
+// it doesn't parse rather than failing the whole message over a
+// malformed Cc/To.
+func parseAddressList(header string) []string {
+	if header == "" {
+		return nil
+	}
+	addrs, err := mail.ParseAddressList(header)
+	if err != nil {
+		return nil
+	}
+	out := make([]string, len(addrs))
+	for i, addr := range addrs {
+		out[i] = addr.Address
+	}
+	return out
+}
+
+// parseMultipart recursively walks a multipart body identified by
+// boundary, setting email.Content from its first text/plain part (or, if
+// there is none, its first text/html part) and appending an Attachment for
+// every part with a filename, an attachment disposition or a non-text
+// media type. Any other text part is dropped.
+func parseMultipart(email *models.Email, body io.Reader, boundary string) error {
+	if boundary == "" {
+		return fmt.Errorf("incoming: multipart message is missing a boundary")
+	}
+
+	var htmlFallback string
+	reader := multipart.NewReader(body, boundary)
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("incoming: failed to read multipart body: %w", err)
+		}
+
+		mediaType, params, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if err != nil {
+			mediaType = "text/plain"
+		}
+
+		if strings.HasPrefix(mediaType, "multipart/") {
+			if err := parseMultipart(email, part, params["boundary"]); err != nil {
+				return err
+			}
+			continue
+		}
+
+		disposition, _, _ := mime.ParseMediaType(part.Header.Get("Content-Disposition"))
+		filename := part.FileName()
+
+		if filename == "" && disposition != "attachment" && strings.HasPrefix(mediaType, "text/") {
+			content, err := decodeBody(part, part.Header.Get("Content-Transfer-Encoding"))
+			if err != nil {
+				return fmt.Errorf("incoming: failed to decode multipart text part: %w", err)
+			}
+			switch {
+			case mediaType == "text/plain" && email.Content == "":
+				email.Content = string(content)
+			case mediaType == "text/html" && htmlFallback == "":
+				htmlFallback = string(content)
+			}
+			continue
+		}
+
+		attachment, err := buildAttachment(part, filename, mediaType, disposition)
+		if err != nil {
+			return err
+		}
+		email.Attachments = append(email.Attachments, attachment)
+	}
+
+	if email.Content == "" {
+		email.Content = htmlFallback
+	}
+	return nil
+}
+
+// buildAttachment decodes part's body and computes its SHA-256 checksum.
+func buildAttachment(part *multipart.Part, filename, mediaType, disposition string) (models.Attachment, error) {
+	content, err := decodeBody(part, part.Header.Get("Content-Transfer-Encoding"))
+	if err != nil {
+		return models.Attachment{}, fmt.Errorf("incoming: failed to decode attachment %q: %w", filename, err)
+	}
+
+	if filename == "" {
+		filename = "attachment"
+	}
+	sum := sha256.Sum256(content)
+
+	return models.Attachment{
+		Filename:    filename,
+		ContentType: mediaType,
+		SizeBytes:   int64(len(content)),
+		Checksum:    hex.EncodeToString(sum[:]),
+		IsInline:    disposition == "inline",
+		ContentID:   strings.Trim(part.Header.Get("Content-Id"), "<>"),
+	}, nil
+}
+
+// decodeBody decodes r according to a Content-Transfer-Encoding value,
+// passing it through unmodified for anything other than base64 or
+// quoted-printable (e.g. "7bit", "8bit", or no encoding at all).
+func decodeBody(r io.Reader, encoding string) ([]byte, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return io.ReadAll(base64.NewDecoder(base64.StdEncoding, r))
+	case "quoted-printable":
+		return io.ReadAll(quotedprintable.NewReader(r))
+	default:
+		return io.ReadAll(r)
+	}
+}
@@ -0,0 +1,56 @@
+package incoming
+
+import (
+	"regexp"
+	"strings"
+)
+
+// replyTokenPattern matches a reply+<token>@domain recipient address,
+// where token is the signed identifier BuildReplyAddress embeds so a
+// reply can be routed back to its thread without relying on the
+// In-Reply-To/References headers every client preserves faithfully.
+var replyTokenPattern = regexp.MustCompile(`(?i)^reply\+([A-Za-z0-9_-]+)@(.+)$`)
+
+// extractReplyToken returns the token embedded in whichever of
+// toAddresses is a reply+<token>@domain address, or "" if none match. If
+// domain is non-empty, only an address at that domain is considered.
+func extractReplyToken(toAddresses []string, domain string) string {
+	for _, addr := range toAddresses {
+		m := replyTokenPattern.FindStringSubmatch(addr)
+		if m == nil {
+			continue
+		}
+		if domain != "" && !strings.EqualFold(m[2], domain) {
+			continue
+		}
+		return m[1]
+	}
+	return ""
+}
+
+// messageIDPattern extracts each Message-ID enclosed in angle brackets
+// from an In-Reply-To or References header.
+var messageIDPattern = regexp.MustCompile(`<([^<>]+)>`)
+
+// referencesFromHeaders returns every Message-ID referenced by an
+// incoming message's In-Reply-To and References headers, used to locate
+// the thread it's replying to.
+func referencesFromHeaders(headers map[string]string) []string {
+	var refs []string
+	if inReplyTo := strings.TrimSpace(headers["In-Reply-To"]); inReplyTo != "" {
+		refs = append(refs, extractMessageIDs(inReplyTo)...)
+	}
+	if references := strings.TrimSpace(headers["References"]); references != "" {
+		refs = append(refs, extractMessageIDs(references)...)
+	}
+	return refs
+}
+
+func extractMessageIDs(header string) []string {
+	matches := messageIDPattern.FindAllStringSubmatch(header, -1)
+	ids := make([]string, 0, len(matches))
+	for _, m := range matches {
+		ids = append(ids, m[1])
+	}
+	return ids
+}
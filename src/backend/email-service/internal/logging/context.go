@@ -0,0 +1,60 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// ctxKey is an unexported type to keep request-scoped values out of other
+// packages' context key spaces.
+type ctxKey struct{}
+
+// RequestAttrs holds the per-request attributes threaded through context and
+// attached to every log line emitted while handling a request.
+type RequestAttrs struct {
+	TraceID   string
+	AccountID string
+	ShardID   int
+	Method    string
+	Endpoint  string
+}
+
+// WithRequestAttrs returns a context carrying attrs for FromContext to pick
+// up later in the request's lifecycle (e.g. deep inside the repository
+// layer).
+func WithRequestAttrs(ctx context.Context, attrs RequestAttrs) context.Context {
+	return context.WithValue(ctx, ctxKey{}, attrs)
+}
+
+// FromContext returns logger with the request-scoped attributes stashed in
+// ctx (trace_id, account_id, shard_id, method, endpoint) attached, so every
+// log line taken against the returned logger carries them automatically. If
+// ctx carries no request attributes, logger is returned unchanged.
+func FromContext(ctx context.Context, logger *slog.Logger) *slog.Logger {
+	attrs, ok := ctx.Value(ctxKey{}).(RequestAttrs)
+	if !ok {
+		return logger
+	}
+
+	var fields []any
+	if attrs.TraceID != "" {
+		fields = append(fields, slog.String("trace_id", attrs.TraceID))
+	}
+	if attrs.AccountID != "" {
+		fields = append(fields, slog.String("account_id", attrs.AccountID))
+	}
+	if attrs.ShardID != 0 {
+		fields = append(fields, slog.Int("shard_id", attrs.ShardID))
+	}
+	if attrs.Method != "" {
+		fields = append(fields, slog.String("method", attrs.Method))
+	}
+	if attrs.Endpoint != "" {
+		fields = append(fields, slog.String("endpoint", attrs.Endpoint))
+	}
+
+	if len(fields) == 0 {
+		return logger
+	}
+	return logger.With(fields...)
+}
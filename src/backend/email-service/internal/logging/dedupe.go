@@ -0,0 +1,149 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dedupeEntry tracks the suppression state for one record key.
+type dedupeEntry struct {
+	firstSeen   time.Time
+	lastSeen    time.Time
+	suppressed  int
+	sampleLevel slog.Level
+}
+
+// DedupingHandler wraps another slog.Handler and collapses bursts of
+// repeated error records. Records at or above slog.LevelError are keyed by
+// their message plus "op" and "error" attributes (when present); the first
+// occurrence of a key within the window is passed through unchanged, and
+// subsequent occurrences are suppressed until the window elapses. A single
+// summary record reporting the suppressed count is emitted just before the
+// first occurrence of that key after the window, or by Flush; no timer
+// emits it on its own.
+type DedupingHandler struct {
+	next   slog.Handler
+	window time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*dedupeEntry
+}
+
+// NewDedupingHandler wraps next, suppressing repeated error records with the
+// same op+error key for window before emitting a count summary. A zero
+// window disables deduplication; every record is passed through unchanged.
+func NewDedupingHandler(next slog.Handler, window time.Duration) *DedupingHandler {
+	return &DedupingHandler{
+		next:    next,
+		window:  window,
+		entries: make(map[string]*dedupeEntry),
+	}
+}
+
+// Enabled implements slog.Handler.
+func (h *DedupingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *DedupingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &DedupingHandler{next: h.next.WithAttrs(attrs), window: h.window, entries: h.entries}
+}
+
+// WithGroup implements slog.Handler.
+func (h *DedupingHandler) WithGroup(name string) slog.Handler {
+	return &DedupingHandler{next: h.next.WithGroup(name), window: h.window, entries: h.entries}
+}
+
+// Handle implements slog.Handler.
+func (h *DedupingHandler) Handle(ctx context.Context, record slog.Record) error {
+	if h.window <= 0 || record.Level < slog.LevelError {
+		return h.next.Handle(ctx, record)
+	}
+
+	key := dedupeKey(record)
+	now := record.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	h.mu.Lock()
+	entry, ok := h.entries[key]
+	if !ok || now.Sub(entry.firstSeen) >= h.window {
+		var flushed *dedupeEntry
+		if ok && entry.suppressed > 0 {
+			flushed = entry
+		}
+		h.entries[key] = &dedupeEntry{firstSeen: now, lastSeen: now, sampleLevel: record.Level}
+		h.mu.Unlock()
+
+		if flushed != nil {
+			if err := h.next.Handle(ctx, summaryRecord(key, flushed)); err != nil {
+				return err
+			}
+		}
+		return h.next.Handle(ctx, record)
+	}
+
+	entry.suppressed++
+	entry.lastSeen = now
+	h.mu.Unlock()
+	return nil
+}
+
+// Flush emits a summary record for every key currently suppressing
+// duplicates, then clears the dedupe state. Callers should invoke this
+// during graceful shutdown so a burst in-flight when the process exits
+// isn't lost silently.
+func (h *DedupingHandler) Flush(ctx context.Context) error {
+	h.mu.Lock()
+	pending := h.entries
+	h.entries = make(map[string]*dedupeEntry)
+	h.mu.Unlock()
+
+	for key, entry := range pending {
+		if entry.suppressed == 0 {
+			continue
+		}
+		if err := h.next.Handle(ctx, summaryRecord(key, entry)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dedupeKey derives a suppression key from the record's message and its
+// "op" and "error" attributes, if present.
+func dedupeKey(record slog.Record) string {
+	var b strings.Builder
+	b.WriteString(record.Message)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		switch attr.Key {
+		case "op", "error":
+			b.WriteByte('|')
+			b.WriteString(attr.Key)
+			b.WriteByte('=')
+			b.WriteString(attr.Value.String())
+		}
+		return true
+	})
+
+	return b.String()
+}
+
+// summaryRecord builds the count-summary record emitted when a burst of
+// duplicates under key finally flushes.
+func summaryRecord(key string, entry *dedupeEntry) slog.Record {
+	summary := slog.NewRecord(entry.lastSeen, entry.sampleLevel, "suppressed repeated log records", 0)
+	summary.AddAttrs(
+		slog.String("dedupe_key", key),
+		slog.Int("suppressed_count", entry.suppressed),
+		slog.Time("first_seen", entry.firstSeen),
+		slog.Time("last_seen", entry.lastSeen),
+	)
+	return summary
+}
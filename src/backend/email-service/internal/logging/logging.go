@@ -0,0 +1,104 @@
+// Package logging provides the service's structured logging setup, built on
+// top of the standard library's log/slog. It adds request-scoped attribute
+// propagation via context and a deduplicating handler that collapses bursts
+// of repeated error records during an outage.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// Format selects the on-wire encoding for log records.
+type Format string
+
+const (
+	FormatJSON    Format = "json"
+	FormatLogfmt  Format = "logfmt"
+	defaultFormat Format = FormatJSON
+	defaultLevel         = slog.LevelInfo
+
+	// DefaultDedupeWindow is how long a repeated error record is suppressed
+	// before its count summary is flushed.
+	DefaultDedupeWindow = time.Minute
+)
+
+// Options configures New.
+type Options struct {
+	// Format is "json" or "logfmt". Defaults to json.
+	Format Format
+	// Level is one of debug, info, warn, error. Defaults to info.
+	Level string
+	// DedupeWindow overrides DefaultDedupeWindow. A zero value uses
+	// DefaultDedupeWindow; a negative value disables deduplication entirely.
+	DedupeWindow time.Duration
+	// Output defaults to os.Stderr.
+	Output io.Writer
+}
+
+// New builds the service's root *slog.Logger from the given options,
+// wrapping the base handler with a deduplicating handler so repeated error
+// records (e.g. "failed to insert email" during an outage) don't flood logs.
+func New(opts Options) (*slog.Logger, error) {
+	level, err := parseLevel(opts.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	out := opts.Output
+	if out == nil {
+		out = os.Stderr
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: level}
+
+	var base slog.Handler
+	switch opts.Format {
+	case "", defaultFormat:
+		base = slog.NewJSONHandler(out, handlerOpts)
+	case FormatLogfmt:
+		base = slog.NewTextHandler(out, handlerOpts)
+	default:
+		return nil, fmt.Errorf("unsupported log format: %s", opts.Format)
+	}
+
+	dedupeWindow := opts.DedupeWindow
+	if dedupeWindow == 0 {
+		dedupeWindow = DefaultDedupeWindow
+	}
+
+	return slog.New(NewDedupingHandler(base, dedupeWindow)), nil
+}
+
+// Flush emits count summaries for any records currently being suppressed by
+// logger's deduping handler. Callers should invoke this during graceful
+// shutdown. Loggers not built with New (and so not backed by a
+// DedupingHandler) are a no-op.
+func Flush(ctx context.Context, logger *slog.Logger) error {
+	if dedupe, ok := logger.Handler().(*DedupingHandler); ok {
+		return dedupe.Flush(ctx)
+	}
+	return nil
+}
+
+// parseLevel maps the --log.level flag value onto a slog.Level.
+func parseLevel(level string) (slog.Level, error) {
+	switch level {
+	case "":
+		return defaultLevel, nil
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("invalid log level: %s", level)
+	}
+}
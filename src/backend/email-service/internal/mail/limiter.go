@@ -0,0 +1,54 @@
+package mail
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate" // v0.3.0
+)
+
+// domainLimiter hands out a per-sender-domain token bucket, so a burst of
+// outbound mail from one domain can't starve another domain's rate budget
+// (and so a single throttled provider account doesn't trip every sender).
+type domainLimiter struct {
+	ratePerSecond rate.Limit
+	burst         int
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// newDomainLimiter creates a limiter factory handing out one
+// ratePerSecond/burst token bucket per from_address domain.
+func newDomainLimiter(ratePerSecond float64, burst int) *domainLimiter {
+	return &domainLimiter{
+		ratePerSecond: rate.Limit(ratePerSecond),
+		burst:         burst,
+		limiters:      make(map[string]*rate.Limiter),
+	}
+}
+
+// forAddress returns the token bucket for addr's domain, creating one on
+// first use.
+func (d *domainLimiter) forAddress(addr string) *rate.Limiter {
+	domain := domainOf(addr)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	limiter, ok := d.limiters[domain]
+	if !ok {
+		limiter = rate.NewLimiter(d.ratePerSecond, d.burst)
+		d.limiters[domain] = limiter
+	}
+	return limiter
+}
+
+// domainOf extracts the domain portion of an email address, returning the
+// whole address if it doesn't contain an "@".
+func domainOf(addr string) string {
+	if i := strings.LastIndex(addr, "@"); i >= 0 {
+		return strings.ToLower(addr[i+1:])
+	}
+	return strings.ToLower(addr)
+}
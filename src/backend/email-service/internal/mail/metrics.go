@@ -0,0 +1,28 @@
+package mail
+
+import (
+	"github.com/prometheus/client_golang/prometheus" // v1.16.0
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	sendsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mail_dispatcher_sends_total",
+		Help: "Total number of emails successfully handed off to a transport",
+	}, []string{"transport"})
+
+	sendFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mail_dispatcher_send_failures_total",
+		Help: "Total number of send attempts that failed",
+	}, []string{"transport"})
+
+	sendRetriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mail_dispatcher_send_retries_total",
+		Help: "Total number of emails re-queued for a retry after a failed send",
+	}, []string{"transport"})
+
+	sendDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "mail_dispatcher_send_duration_seconds",
+		Help: "Duration of a single Transport.Send call",
+	}, []string{"transport"})
+)
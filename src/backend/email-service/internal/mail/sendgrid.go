@@ -0,0 +1,61 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/sendgrid/sendgrid-go" // v3.14.0
+	"github.com/sendgrid/sendgrid-go/helpers/mail" // v3.14.0
+
+	"github.com/email-management-platform/backend/email-service/internal/config"
+	internalmodels "github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// SendGridTransport delivers mail through the SendGrid v3 Mail Send API.
+type SendGridTransport struct {
+	client *sendgrid.Client
+}
+
+// SendGridTransport implements Transport.
+var _ Transport = (*SendGridTransport)(nil)
+
+// NewSendGridTransport creates a transport authenticated with cfg.APIKey.
+func NewSendGridTransport(cfg config.SendGridConfig) *SendGridTransport {
+	return &SendGridTransport{client: sendgrid.NewSendClient(cfg.APIKey)}
+}
+
+// Send submits email via SendGrid's Mail Send API and returns the
+// X-Message-Id assigned by the provider.
+func (t *SendGridTransport) Send(ctx context.Context, email *internalmodels.Email) (string, error) {
+	from := mail.NewEmail("", email.FromAddress)
+	message := mail.NewV3Mail()
+	message.SetFrom(from)
+	message.Subject = email.Subject
+
+	personalization := mail.NewPersonalization()
+	for _, to := range email.ToAddresses {
+		personalization.AddTos(mail.NewEmail("", to))
+	}
+	for _, cc := range email.CCAddresses {
+		personalization.AddCCs(mail.NewEmail("", cc))
+	}
+	for _, bcc := range email.BCCAddresses {
+		personalization.AddBCCs(mail.NewEmail("", bcc))
+	}
+	message.AddPersonalizations(personalization)
+	message.AddContent(mail.NewContent("text/plain", email.Content))
+
+	response, err := t.client.SendWithContext(ctx, message)
+	if err != nil {
+		return "", fmt.Errorf("sendgrid: failed to send message: %w", err)
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		return "", fmt.Errorf("sendgrid: send rejected with status %d: %s", response.StatusCode, response.Body)
+	}
+
+	if ids := response.Headers["X-Message-Id"]; len(ids) > 0 {
+		return ids[0], nil
+	}
+	return "", nil
+}
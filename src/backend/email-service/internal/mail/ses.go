@@ -0,0 +1,70 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws" // v1.22.2
+	awsconfig "github.com/aws/aws-sdk-go-v2/config" // v1.22.2
+	"github.com/aws/aws-sdk-go-v2/service/sesv2" // v1.19.2
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+
+	"github.com/email-management-platform/backend/email-service/internal/config"
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// SESTransport delivers mail through Amazon SES v2's SendEmail API.
+type SESTransport struct {
+	cfg    config.SESConfig
+	client *sesv2.Client
+}
+
+// SESTransport implements Transport.
+var _ Transport = (*SESTransport)(nil)
+
+// NewSESTransport creates a transport backed by the default AWS credential
+// chain (environment, shared config, instance role), scoped to cfg.Region.
+func NewSESTransport(cfg config.SESConfig) *SESTransport {
+	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(cfg.Region))
+	if err != nil {
+		// Deferring the error to Send keeps NewSESTransport's signature
+		// consistent with the other transports; a nil client fails there.
+		return &SESTransport{cfg: cfg}
+	}
+	return &SESTransport{cfg: cfg, client: sesv2.NewFromConfig(awsCfg)}
+}
+
+// Send submits email as a simple (non-MIME) SES message and returns the
+// provider-assigned SES message ID.
+func (t *SESTransport) Send(ctx context.Context, email *models.Email) (string, error) {
+	if t.client == nil {
+		return "", fmt.Errorf("ses: client was not initialized, check region %q", t.cfg.Region)
+	}
+
+	input := &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(email.FromAddress),
+		Destination: &types.Destination{
+			ToAddresses:  email.ToAddresses,
+			CcAddresses:  email.CCAddresses,
+			BccAddresses: email.BCCAddresses,
+		},
+		Content: &types.EmailContent{
+			Simple: &types.Message{
+				Subject: &types.Content{Data: aws.String(email.Subject)},
+				Body: &types.Body{
+					Text: &types.Content{Data: aws.String(email.Content)},
+				},
+			},
+		},
+	}
+	if t.cfg.ConfigurationSet != "" {
+		input.ConfigurationSetName = aws.String(t.cfg.ConfigurationSet)
+	}
+
+	out, err := t.client.SendEmail(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("ses: failed to send message: %w", err)
+	}
+
+	return aws.ToString(out.MessageId), nil
+}
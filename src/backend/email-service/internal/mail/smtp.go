@@ -0,0 +1,62 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/email-management-platform/backend/email-service/internal/config"
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// SMTPTransport delivers mail through a single SMTP relay using PLAIN auth.
+// It has no notion of a provider-assigned message ID, so Send returns the
+// email's own MessageID for correlation.
+type SMTPTransport struct {
+	cfg config.SMTPConfig
+}
+
+// SMTPTransport implements Transport.
+var _ Transport = (*SMTPTransport)(nil)
+
+// NewSMTPTransport creates a transport that relays through cfg.Host:cfg.Port.
+func NewSMTPTransport(cfg config.SMTPConfig) *SMTPTransport {
+	return &SMTPTransport{cfg: cfg}
+}
+
+// Send builds a minimal RFC 5322 message and hands it to the relay via
+// smtp.SendMail. ctx is not honored by net/smtp, which has no deadline
+// support; callers relying on ctx cancellation should wrap this transport.
+func (t *SMTPTransport) Send(ctx context.Context, email *models.Email) (string, error) {
+	addr := fmt.Sprintf("%s:%d", t.cfg.Host, t.cfg.Port)
+
+	var auth smtp.Auth
+	if t.cfg.Username != "" {
+		auth = smtp.PlainAuth("", t.cfg.Username, t.cfg.Password, t.cfg.Host)
+	}
+
+	recipients := make([]string, 0, len(email.ToAddresses)+len(email.CCAddresses)+len(email.BCCAddresses))
+	recipients = append(recipients, email.ToAddresses...)
+	recipients = append(recipients, email.CCAddresses...)
+	recipients = append(recipients, email.BCCAddresses...)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\n", email.FromAddress)
+	fmt.Fprintf(&body, "To: %s\r\n", strings.Join(email.ToAddresses, ", "))
+	if len(email.CCAddresses) > 0 {
+		fmt.Fprintf(&body, "Cc: %s\r\n", strings.Join(email.CCAddresses, ", "))
+	}
+	fmt.Fprintf(&body, "Subject: %s\r\n", email.Subject)
+	for k, v := range email.Headers {
+		fmt.Fprintf(&body, "%s: %s\r\n", k, v)
+	}
+	body.WriteString("\r\n")
+	body.WriteString(email.Content)
+
+	if err := smtp.SendMail(addr, auth, email.FromAddress, recipients, []byte(body.String())); err != nil {
+		return "", fmt.Errorf("smtp: failed to send message: %w", err)
+	}
+
+	return email.MessageID, nil
+}
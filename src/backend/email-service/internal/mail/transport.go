@@ -0,0 +1,38 @@
+// Package mail implements the outbound mail dispatcher: a bounded worker
+// pool that pulls queued emails from the repository in shard-ordered
+// batches and hands each to a pluggable Transport, rate-limiting per
+// sender domain and retrying failed sends with exponential backoff.
+package mail
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/email-management-platform/backend/email-service/internal/config"
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// Transport delivers a single email to a concrete provider (SMTP relay,
+// Amazon SES, SendGrid, ...). Implementations should be safe for
+// concurrent use, since the Dispatcher's worker pool calls Send from
+// multiple goroutines.
+type Transport interface {
+	// Send hands email to the provider. On success it returns the
+	// provider-assigned message ID recorded against the email for later
+	// correlation (bounces, delivery webhooks, support lookups).
+	Send(ctx context.Context, email *models.Email) (providerID string, err error)
+}
+
+// NewTransport constructs the Transport configured by cfg.Transport.
+func NewTransport(cfg config.MailConfig) (Transport, error) {
+	switch cfg.Transport {
+	case "", "smtp":
+		return NewSMTPTransport(cfg.SMTP), nil
+	case "ses":
+		return NewSESTransport(cfg.SES), nil
+	case "sendgrid":
+		return NewSendGridTransport(cfg.SendGrid), nil
+	default:
+		return nil, fmt.Errorf("unknown mail transport: %q", cfg.Transport)
+	}
+}
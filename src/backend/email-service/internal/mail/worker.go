@@ -0,0 +1,180 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/sony/gobreaker" // v0.5.0
+
+	"github.com/email-management-platform/backend/email-service/internal/config"
+	"github.com/email-management-platform/backend/email-service/internal/models"
+	"github.com/email-management-platform/backend/email-service/internal/repositories"
+)
+
+// pollInterval is how often an idle worker re-checks a shard for newly
+// queued or newly-due-for-retry emails.
+const pollInterval = time.Second * 5
+
+// Dispatcher is a bounded worker pool that drains StatusQueued emails from
+// every configured shard and hands each to a Transport, honoring a
+// per-sender-domain rate limit and retrying failed sends with exponential
+// backoff via the email_send_attempts table.
+type Dispatcher struct {
+	cfg       config.MailConfig
+	repo      *repositories.EmailRepository
+	transport Transport
+	limiter   *domainLimiter
+	breaker   *gobreaker.CircuitBreaker
+	shardIDs  []int
+	logger    *slog.Logger
+}
+
+// NewDispatcher wires a Dispatcher for the given shard IDs. logger may be
+// nil, in which case slog.Default() is used.
+func NewDispatcher(cfg config.MailConfig, repo *repositories.EmailRepository, shardIDs []int, logger *slog.Logger) (*Dispatcher, error) {
+	transport, err := NewTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	breaker := gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        "mail-dispatcher-" + cfg.Transport,
+		MaxRequests: 1,
+		Timeout:     time.Minute,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= 3
+		},
+	})
+
+	return &Dispatcher{
+		cfg:       cfg,
+		repo:      repo,
+		transport: transport,
+		limiter:   newDomainLimiter(cfg.RatePerSecond, cfg.RateBurst),
+		breaker:   breaker,
+		shardIDs:  shardIDs,
+		logger:    logger,
+	}, nil
+}
+
+// Run starts one worker per configured shard and blocks until ctx is
+// cancelled, draining every worker before returning.
+func (d *Dispatcher) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, shardID := range d.shardIDs {
+		wg.Add(1)
+		go func(shardID int) {
+			defer wg.Done()
+			d.runShardWorker(ctx, shardID)
+		}(shardID)
+	}
+	wg.Wait()
+}
+
+// runShardWorker repeatedly pulls a batch of queued emails for shardID and
+// sends each, sleeping pollInterval between empty batches.
+func (d *Dispatcher) runShardWorker(ctx context.Context, shardID int) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		batch, err := d.repo.GetQueuedBatch(ctx, shardID, d.cfg.BatchSize, d.cfg.MaxRetries)
+		if err != nil {
+			d.logger.Error("mail dispatcher: failed to fetch queued batch", "shard_id", shardID, "error", err)
+			d.sleep(ctx, pollInterval)
+			continue
+		}
+
+		if len(batch) == 0 {
+			d.sleep(ctx, pollInterval)
+			continue
+		}
+
+		for _, queued := range batch {
+			d.sendOne(ctx, queued)
+		}
+	}
+}
+
+// sendOne waits for the sender domain's rate limiter, then attempts a
+// single send through the circuit breaker, recording success via MarkSent
+// or scheduling a backoff retry via RecordSendFailure.
+func (d *Dispatcher) sendOne(ctx context.Context, queued *repositories.QueuedEmail) {
+	email := queued.Email
+	if err := d.limiter.forAddress(email.FromAddress).Wait(ctx); err != nil {
+		return
+	}
+
+	timer := time.Now()
+	result, sendErr := d.breaker.Execute(func() (interface{}, error) {
+		return d.transport.Send(ctx, email)
+	})
+	sendDuration.WithLabelValues(d.cfg.Transport).Observe(time.Since(timer).Seconds())
+
+	if sendErr != nil {
+		sendFailuresTotal.WithLabelValues(d.cfg.Transport).Inc()
+		d.scheduleRetry(ctx, email, queued.AttemptCount, sendErr)
+		return
+	}
+
+	providerID, _ := result.(string)
+	if err := d.repo.MarkSent(ctx, email.MessageID, email.AccountID, providerID, time.Now()); err != nil {
+		d.logger.Error("mail dispatcher: failed to mark email sent", "message_id", email.MessageID, "error", err)
+		return
+	}
+	sendsTotal.WithLabelValues(d.cfg.Transport).Inc()
+}
+
+// maxRetryBackoff caps the exponential backoff computed in scheduleRetry,
+// so a shard stuck behind a slow-failing transport never waits longer than
+// this between attempts.
+const maxRetryBackoff = time.Hour
+
+// scheduleRetry records the failed attempt and schedules the next attempt
+// with exponential backoff (RetryBackoff * 2^attemptCount, capped at
+// maxRetryBackoff), where attemptCount is how many times email has already
+// failed before this attempt. Once the recorded attempt count reaches
+// cfg.MaxRetries, GetQueuedBatch's retry-ceiling filter stops returning the
+// email, so it's left queued with its last failure recorded for an
+// operator to inspect rather than retried forever.
+func (d *Dispatcher) scheduleRetry(ctx context.Context, email *models.Email, attemptCount int, sendErr error) {
+	backoff := d.cfg.RetryBackoff * time.Duration(1<<uint(attemptCount))
+	if backoff <= 0 || backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	nextAttempt := time.Now().Add(backoff)
+
+	if err := d.repo.RecordSendFailure(ctx, email.MessageID, sendErr, nextAttempt); err != nil {
+		d.logger.Error("mail dispatcher: failed to record send failure", "message_id", email.MessageID, "error", err)
+		return
+	}
+
+	if attemptCount+1 >= d.cfg.MaxRetries {
+		d.logger.Warn("mail dispatcher: send failed, exceeded max retries and will no longer be retried",
+			"message_id", email.MessageID, "attempt_count", attemptCount+1, "error", sendErr)
+		return
+	}
+	sendRetriesTotal.WithLabelValues(d.cfg.Transport).Inc()
+
+	d.logger.Warn("mail dispatcher: send failed, scheduled for retry",
+		"message_id", email.MessageID, "attempt_count", attemptCount+1, "next_attempt_at", nextAttempt, "error", sendErr)
+}
+
+// sleep blocks for interval, returning early if ctx is cancelled.
+func (d *Dispatcher) sleep(ctx context.Context, interval time.Duration) {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
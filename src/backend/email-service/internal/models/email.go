@@ -3,15 +3,26 @@
 package models
 
 import (
+    "bytes"
+    "context"
+    "crypto/hmac"
     "crypto/rand"
-    "encoding/hex"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/binary"
     "errors"
     "fmt"
+    "io"
+    "mime"
+    "mime/multipart"
     "net/mail"
+    "strings"
     "time"
 
     "google.golang.org/protobuf/types/known/timestamppb" // v1.31.0
     emailpb "github.com/email-management-platform/backend/shared/proto/email"
+
+    "github.com/email-management-platform/backend/email-service/internal/cache"
 )
 
 // MaxAttachmentSize defines the maximum allowed size for email attachments (25MB)
@@ -39,6 +50,12 @@ const (
     StatusArchived
     StatusDeleted
     StatusSpam
+    // StatusQueued marks an outgoing email as ready for the mail dispatcher
+    // to pick up and send.
+    StatusQueued
+    // StatusSent marks an outgoing email that has been handed off to a
+    // Transport and accepted by the provider.
+    StatusSent
 )
 
 // Email represents a comprehensive email message with threading and conversation tracking
@@ -63,6 +80,18 @@ type Email struct {
     ReceivedAt     time.Time
     Headers        map[string]string
     Metadata       map[string]string
+
+    // BodyStructure is a terse summary of the email's MIME shape, e.g.
+    // "multipart/mixed(text/plain, application/pdf)". It's computed
+    // lazily: left empty until the first successful GetRFC822 call.
+    BodyStructure string
+
+    // PlainTextAlternative holds a plain-text rendering of Content, for
+    // MIME clients that prefer text/plain over an HTML Content body (e.g.
+    // templates.Renderer populates this by stripping tags from the HTML
+    // it renders). Left empty for emails whose Content is already plain
+    // text.
+    PlainTextAlternative string
 }
 
 // Attachment represents an email attachment with validation capabilities
@@ -222,13 +251,133 @@ func (a *Attachment) Validate() error {
     return nil
 }
 
-// GenerateMessageID creates a new unique message ID
-func GenerateMessageID() (string, error) {
-    bytes := make([]byte, 16)
-    if _, err := rand.Read(bytes); err != nil {
+// GenerateMessageID creates a new RFC 5322 Message-ID for a message sent
+// from domain, formatted as "<uuidv4@domain>".
+func GenerateMessageID(domain string) (string, error) {
+    raw := make([]byte, 16)
+    if _, err := rand.Read(raw); err != nil {
         return "", fmt.Errorf("failed to generate message ID: %w", err)
     }
-    return hex.EncodeToString(bytes), nil
+
+    // Set the version (4) and variant (RFC 4122) bits per the UUIDv4 spec.
+    raw[6] = (raw[6] & 0x0f) | 0x40
+    raw[8] = (raw[8] & 0x3f) | 0x80
+
+    uuid := fmt.Sprintf("%x-%x-%x-%x-%x", raw[0:4], raw[4:6], raw[6:8], raw[8:10], raw[10:16])
+    return fmt.Sprintf("<%s@%s>", uuid, domain), nil
+}
+
+// ReplyTokenValidity bounds how long a token generated by
+// GenerateReplyToken remains valid for VerifyReplyToken.
+var ReplyTokenValidity = time.Hour * 24 * 7
+
+// GenerateReplyToken returns a signed, URL-safe token embedding messageID
+// and accountID, for use with Email.BuildReplyAddress to route a reply
+// back to its originating thread (see internal/incoming's
+// reply+<token>@domain convention). The token is valid for
+// ReplyTokenValidity from the moment it's generated.
+func GenerateReplyToken(messageID, accountID string, secret []byte) string {
+    return generateReplyTokenAt(messageID, accountID, secret, time.Now())
+}
+
+func generateReplyTokenAt(messageID, accountID string, secret []byte, issuedAt time.Time) string {
+    payload := encodeReplyTokenPayload(messageID, accountID, issuedAt)
+
+    mac := hmac.New(sha256.New, secret)
+    mac.Write(payload)
+    signed := append(payload, mac.Sum(nil)...)
+
+    return base64.RawURLEncoding.EncodeToString(signed)
+}
+
+// VerifyReplyToken validates token's signature and expiry against secret
+// and ReplyTokenValidity, returning the messageID and accountID it was
+// generated for. It rejects a token with an invalid or missing signature
+// (forged or corrupted) or one issued further in the past than
+// ReplyTokenValidity, using a constant-time comparison for the signature
+// check so verification timing can't leak it.
+func VerifyReplyToken(token string, secret []byte) (messageID, accountID string, err error) {
+    decoded, err := base64.RawURLEncoding.DecodeString(token)
+    if err != nil {
+        return "", "", fmt.Errorf("models: malformed reply token: %w", err)
+    }
+    if len(decoded) < sha256.Size {
+        return "", "", errors.New("models: malformed reply token")
+    }
+
+    payload := decoded[:len(decoded)-sha256.Size]
+    signature := decoded[len(decoded)-sha256.Size:]
+
+    mac := hmac.New(sha256.New, secret)
+    mac.Write(payload)
+    if !hmac.Equal(signature, mac.Sum(nil)) {
+        return "", "", errors.New("models: reply token signature is invalid")
+    }
+
+    issuedAt, msgID, acctID, err := decodeReplyTokenPayload(payload)
+    if err != nil {
+        return "", "", fmt.Errorf("models: malformed reply token: %w", err)
+    }
+    if time.Since(issuedAt) > ReplyTokenValidity {
+        return "", "", errors.New("models: reply token has expired")
+    }
+
+    return msgID, acctID, nil
+}
+
+// encodeReplyTokenPayload packs issuedAt, messageID, and accountID into a
+// compact binary payload, length-prefixing each string field so
+// decodeReplyTokenPayload can split them back out unambiguously.
+func encodeReplyTokenPayload(messageID, accountID string, issuedAt time.Time) []byte {
+    buf := make([]byte, 0, 8+2+len(messageID)+2+len(accountID))
+
+    var tsBuf [8]byte
+    binary.BigEndian.PutUint64(tsBuf[:], uint64(issuedAt.Unix()))
+    buf = append(buf, tsBuf[:]...)
+
+    var lenBuf [2]byte
+    binary.BigEndian.PutUint16(lenBuf[:], uint16(len(messageID)))
+    buf = append(buf, lenBuf[:]...)
+    buf = append(buf, messageID...)
+
+    binary.BigEndian.PutUint16(lenBuf[:], uint16(len(accountID)))
+    buf = append(buf, lenBuf[:]...)
+    buf = append(buf, accountID...)
+
+    return buf
+}
+
+// decodeReplyTokenPayload is the inverse of encodeReplyTokenPayload.
+func decodeReplyTokenPayload(payload []byte) (issuedAt time.Time, messageID, accountID string, err error) {
+    if len(payload) < 10 {
+        return time.Time{}, "", "", errors.New("payload too short")
+    }
+    issuedAt = time.Unix(int64(binary.BigEndian.Uint64(payload[:8])), 0)
+    rest := payload[8:]
+
+    msgLen := int(binary.BigEndian.Uint16(rest[:2]))
+    rest = rest[2:]
+    if len(rest) < msgLen+2 {
+        return time.Time{}, "", "", errors.New("payload too short")
+    }
+    messageID = string(rest[:msgLen])
+    rest = rest[msgLen:]
+
+    acctLen := int(binary.BigEndian.Uint16(rest[:2]))
+    rest = rest[2:]
+    if len(rest) < acctLen {
+        return time.Time{}, "", "", errors.New("payload too short")
+    }
+    accountID = string(rest[:acctLen])
+
+    return issuedAt, messageID, accountID, nil
+}
+
+// BuildReplyAddress returns a "reply+<token>@base" address embedding
+// token, suitable as a recipient internal/incoming's extractReplyToken can
+// route back to this email's thread.
+func (e *Email) BuildReplyAddress(base, token string) string {
+    return fmt.Sprintf("reply+%s@%s", token, base)
 }
 
 // IsPartOfThread checks if the email belongs to a thread
@@ -243,8 +392,8 @@ func (e *Email) GetConversationContext() map[string]string {
     }
     context := make(map[string]string)
     for k, v := range e.Metadata {
-        if k[:5] == "conv_" {
-            context[k[5:]] = v
+        if strings.HasPrefix(k, "conv_") {
+            context[strings.TrimPrefix(k, "conv_")] = v
         }
     }
     return context
@@ -253,4 +402,169 @@ func (e *Email) GetConversationContext() map[string]string {
 // VerifyChecksum validates the attachment's integrity
 func (a *Attachment) VerifyChecksum(providedChecksum string) bool {
     return a.Checksum == providedChecksum
+}
+
+// ChangeType represents the kind of mutation an incremental sync observed
+type ChangeType int32
+
+// Change type constants returned by provider incremental sync
+const (
+    ChangeUnspecified ChangeType = iota
+    ChangeAdded
+    ChangeModified
+    ChangeDeleted
+    ChangeLabelChanged
+)
+
+// EmailChange represents a single mutation surfaced by a provider's
+// incremental sync API (Gmail History API, Microsoft Graph delta query).
+type EmailChange struct {
+    MessageID string
+    Change    ChangeType
+    Email     *Email
+}
+
+// OutgoingEmail represents a message to be sent or replied with, before a
+// provider assigns it a MessageID. Unlike Email, it carries a plain-text and
+// HTML body pair so providers can build an appropriate MIME payload.
+type OutgoingEmail struct {
+    Subject     string
+    TextBody    string
+    HTMLBody    string
+    FromAddress string
+    ToAddresses []string
+    CCAddresses []string
+    BCCAddresses []string
+    Attachments []Attachment
+    Headers     map[string]string
+}
+
+// BatchError reports per-item failures from a batched provider call
+// (e.g. GetEmailsBatch) without failing the entire batch. Errors maps the
+// index of the failed item within the original request slice to the error
+// that occurred for it.
+type BatchError struct {
+    Errors map[int]error
+}
+
+// Error implements the error interface, summarizing how many of the
+// requested items failed.
+func (e *BatchError) Error() string {
+    return fmt.Sprintf("%d batch item(s) failed", len(e.Errors))
+}
+
+// GetRFC822 returns the email's full raw RFC822 body, preferring store's
+// cached copy and falling back to primary on a miss. A successful
+// primary fetch is written back to store (best effort: a write failure
+// doesn't fail the call) and, on its first call, computes BodyStructure
+// from the body it fetched. store may be nil to bypass the cache
+// entirely.
+func (e *Email) GetRFC822(ctx context.Context, store *cache.Store, primary cache.PrimaryStore) ([]byte, error) {
+    if store != nil {
+        if body, found, err := store.GetMessage(ctx, e.AccountID, e.MessageID); err == nil && found {
+            if e.BodyStructure == "" {
+                e.BodyStructure = computeBodyStructure(body)
+            }
+            return body, nil
+        }
+    }
+
+    if primary == nil {
+        return nil, errors.New("email: no cached copy and no primary store configured")
+    }
+
+    body, err := primary.FetchRFC822(ctx, e.MessageID)
+    if err != nil {
+        return nil, fmt.Errorf("email: failed to fetch rfc822 body: %w", err)
+    }
+
+    if e.BodyStructure == "" {
+        e.BodyStructure = computeBodyStructure(body)
+    }
+    if store != nil {
+        store.PutMessage(ctx, e.AccountID, e.MessageID, body)
+    }
+    return body, nil
+}
+
+// GetBody returns the attachment's raw body, preferring store's cached
+// copy and falling back to primary on a miss. accountID identifies the
+// owning user for cache keying, since Attachment itself doesn't carry
+// one. store may be nil to bypass the cache entirely.
+func (a *Attachment) GetBody(ctx context.Context, accountID string, store *cache.Store, primary cache.PrimaryStore) ([]byte, error) {
+    if store != nil {
+        if body, found, err := store.GetAttachment(ctx, accountID, a.AttachmentID); err == nil && found {
+            return body, nil
+        }
+    }
+
+    if primary == nil {
+        return nil, errors.New("email: no cached copy and no primary store configured")
+    }
+
+    body, err := primary.FetchAttachmentBody(ctx, a.AttachmentID)
+    if err != nil {
+        return nil, fmt.Errorf("email: failed to fetch attachment body: %w", err)
+    }
+
+    if store != nil {
+        store.PutAttachment(ctx, accountID, a.AttachmentID, body)
+    }
+    return body, nil
+}
+
+// computeBodyStructure returns a terse summary of raw's MIME shape, or ""
+// if raw isn't a parseable message. Top-level content is represented by
+// its media type; a multipart body is represented as
+// "<media type>(<part media types>)", recursing into nested multipart
+// parts.
+func computeBodyStructure(raw []byte) string {
+    msg, err := mail.ReadMessage(bytes.NewReader(raw))
+    if err != nil {
+        return ""
+    }
+
+    mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+    if err != nil {
+        return "text/plain"
+    }
+    if !strings.HasPrefix(mediaType, "multipart/") {
+        return mediaType
+    }
+
+    parts := collectPartTypes(msg.Body, params["boundary"])
+    return fmt.Sprintf("%s(%s)", mediaType, strings.Join(parts, ", "))
+}
+
+// collectPartTypes returns the media type of every leaf part in a
+// multipart body identified by boundary, recursing into nested
+// multipart parts.
+func collectPartTypes(body io.Reader, boundary string) []string {
+    if boundary == "" {
+        return nil
+    }
+
+    var types []string
+    reader := multipart.NewReader(body, boundary)
+    for {
+        part, err := reader.NextPart()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            break
+        }
+
+        mediaType, params, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+        if err != nil {
+            mediaType = "text/plain"
+        }
+
+        if strings.HasPrefix(mediaType, "multipart/") {
+            types = append(types, collectPartTypes(part, params["boundary"])...)
+            continue
+        }
+        types = append(types, mediaType)
+    }
+    return types
 }
\ No newline at end of file
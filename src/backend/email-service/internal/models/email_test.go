@@ -0,0 +1,102 @@
+package models
+
+import (
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestGenerateMessageIDFormat(t *testing.T) {
+    id, err := GenerateMessageID("example.com")
+    if err != nil {
+        t.Fatalf("GenerateMessageID returned an error: %v", err)
+    }
+    if !strings.HasPrefix(id, "<") || !strings.HasSuffix(id, "@example.com>") {
+        t.Fatalf("expected a \"<uuid@example.com>\" message ID, got %q", id)
+    }
+}
+
+func TestVerifyReplyTokenRoundTrip(t *testing.T) {
+    secret := []byte("test-secret")
+    token := GenerateReplyToken("msg-123", "account-456", secret)
+
+    messageID, accountID, err := VerifyReplyToken(token, secret)
+    if err != nil {
+        t.Fatalf("VerifyReplyToken failed on a freshly generated token: %v", err)
+    }
+    if messageID != "msg-123" || accountID != "account-456" {
+        t.Fatalf("expected (msg-123, account-456), got (%s, %s)", messageID, accountID)
+    }
+}
+
+func TestVerifyReplyTokenRejectsForgedSignature(t *testing.T) {
+    secret := []byte("test-secret")
+    token := GenerateReplyToken("msg-123", "account-456", secret)
+
+    if _, _, err := VerifyReplyToken(token, []byte("wrong-secret")); err == nil {
+        t.Fatal("expected VerifyReplyToken to reject a token signed with a different secret")
+    }
+
+    // Change a character in the middle: every bit of it is data, unlike the
+    // last character, whose padding bits the decoder ignores.
+    i := len(token) / 2
+    replacement := "A"
+    if token[i] == 'A' {
+        replacement = "B"
+    }
+    tampered := token[:i] + replacement + token[i+1:]
+    if tampered == token {
+        t.Fatal("expected the tampered token to differ from the original")
+    }
+    if _, _, err := VerifyReplyToken(tampered, secret); err == nil {
+        t.Fatal("expected VerifyReplyToken to reject a tampered token")
+    }
+}
+
+func TestVerifyReplyTokenRejectsExpiredToken(t *testing.T) {
+    secret := []byte("test-secret")
+    originalValidity := ReplyTokenValidity
+    ReplyTokenValidity = time.Minute
+    defer func() { ReplyTokenValidity = originalValidity }()
+
+    token := generateReplyTokenAt("msg-123", "account-456", secret, time.Now().Add(-time.Hour))
+
+    if _, _, err := VerifyReplyToken(token, secret); err == nil {
+        t.Fatal("expected VerifyReplyToken to reject a token issued outside the validity window")
+    }
+}
+
+func TestVerifyReplyTokenRejectsMalformedToken(t *testing.T) {
+    if _, _, err := VerifyReplyToken("not-a-valid-token!!", []byte("secret")); err == nil {
+        t.Fatal("expected VerifyReplyToken to reject a non-base64 token")
+    }
+    if _, _, err := VerifyReplyToken("dG9vc2hvcnQ", []byte("secret")); err == nil {
+        t.Fatal("expected VerifyReplyToken to reject a token too short to contain a signature")
+    }
+}
+
+func TestGetConversationContextHandlesShortKeys(t *testing.T) {
+    e := &Email{
+        Metadata: map[string]string{
+            "a":           "short key, shorter than the conv_ prefix",
+            "conv_thread": "thread-value",
+            "other":       "ignored",
+        },
+    }
+
+    context := e.GetConversationContext()
+    if context["thread"] != "thread-value" {
+        t.Fatalf("expected conv_ prefixed keys to be stripped and kept, got %#v", context)
+    }
+    if _, ok := context["a"]; ok {
+        t.Fatalf("expected non-conv_ keys to be excluded, got %#v", context)
+    }
+}
+
+func TestBuildReplyAddress(t *testing.T) {
+    e := &Email{}
+    addr := e.BuildReplyAddress("example.com", "abc123")
+    if addr != "reply+abc123@example.com" {
+        t.Fatalf("expected reply+abc123@example.com, got %q", addr)
+    }
+}
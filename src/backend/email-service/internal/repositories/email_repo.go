@@ -7,6 +7,9 @@ import (
     "database/sql"
     "encoding/json"
     "fmt"
+    "log/slog"
+    "sync"
+    "sync/atomic"
     "time"
 
     "github.com/lib/pq" // v1.10.9
@@ -15,6 +18,7 @@ import (
     "github.com/prometheus/client_golang/prometheus/promauto"
 
     "github.com/email-management-platform/backend/email-service/internal/config"
+    "github.com/email-management-platform/backend/email-service/internal/logging"
     "github.com/email-management-platform/backend/email-service/internal/models"
 )
 
@@ -30,6 +34,10 @@ const (
 
     // Base delay for exponential backoff
     retryBackoff = time.Millisecond * 100
+
+    // Defaults for the bulk-write worker pool
+    defaultWorkerCount   = 4
+    defaultQueueCapacity = 1000
 )
 
 // Metrics collectors
@@ -43,6 +51,27 @@ var (
         Name: "email_repository_operation_errors_total",
         Help: "Total number of email repository operation errors",
     }, []string{"operation"})
+
+    bulkWorkerProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "email_repository_bulk_worker_processed_total",
+        Help: "Total number of emails processed by each bulk-write worker",
+    }, []string{"worker"})
+
+    bulkWorkerFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "email_repository_bulk_worker_failed_total",
+        Help: "Total number of emails that failed processing in a bulk-write worker",
+    }, []string{"worker"})
+
+    bulkWorkerRetried = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "email_repository_bulk_worker_retried_total",
+        Help: "Total number of emails retried by a bulk-write worker",
+    }, []string{"worker"})
+
+    bulkBatchSize = promauto.NewHistogram(prometheus.HistogramOpts{
+        Name:    "email_repository_bulk_batch_size",
+        Help:    "Size of batches committed by the bulk-write worker pool",
+        Buckets: prometheus.LinearBuckets(10, 10, 10),
+    })
 )
 
 // EmailRepository handles email data persistence with sharding support
@@ -51,6 +80,9 @@ type EmailRepository struct {
     shardMgr      *ShardManager
     preparedStmts map[string]*sql.Stmt
     metrics       *metrics
+    workerPool    config.WorkerPoolConfig
+    inFlight      sync.Map // messageID -> struct{}, a "don't look twice" guard
+    logger        *slog.Logger
 }
 
 // metrics holds repository operation metrics
@@ -59,12 +91,34 @@ type metrics struct {
     errors   *prometheus.CounterVec
 }
 
-// NewEmailRepository creates a new EmailRepository instance
-func NewEmailRepository(db *sql.DB, shardCfg *config.ShardConfig) (*EmailRepository, error) {
+// NewEmailRepository creates a new EmailRepository instance. logger may be
+// nil, in which case slog.Default() is used.
+func NewEmailRepository(db *sql.DB, shardCfg *config.ShardConfig, logger *slog.Logger) (*EmailRepository, error) {
+    return NewEmailRepositoryWithWorkerPool(db, shardCfg, config.WorkerPoolConfig{}, logger)
+}
+
+// NewEmailRepositoryWithWorkerPool creates a new EmailRepository instance,
+// overriding the default bulk-write worker pool sizing. logger may be nil,
+// in which case slog.Default() is used.
+func NewEmailRepositoryWithWorkerPool(db *sql.DB, shardCfg *config.ShardConfig, poolCfg config.WorkerPoolConfig, logger *slog.Logger) (*EmailRepository, error) {
     if db == nil {
         return nil, errors.New("database connection is required")
     }
 
+    if logger == nil {
+        logger = slog.Default()
+    }
+
+    if poolCfg.WorkerCount <= 0 {
+        poolCfg.WorkerCount = defaultWorkerCount
+    }
+    if poolCfg.QueueCapacity <= 0 {
+        poolCfg.QueueCapacity = defaultQueueCapacity
+    }
+    if poolCfg.BatchSize <= 0 {
+        poolCfg.BatchSize = defaultBatchSize
+    }
+
     // Initialize shard manager
     shardMgr, err := NewShardManager(shardCfg)
     if err != nil {
@@ -81,6 +135,8 @@ func NewEmailRepository(db *sql.DB, shardCfg *config.ShardConfig) (*EmailReposit
         db:            db,
         shardMgr:      shardMgr,
         preparedStmts: stmts,
+        workerPool:    poolCfg,
+        logger:        logger,
         metrics: &metrics{
             duration: emailOperationDuration,
             errors:   emailOperationErrors,
@@ -90,6 +146,17 @@ func NewEmailRepository(db *sql.DB, shardCfg *config.ShardConfig) (*EmailReposit
     return repo, nil
 }
 
+// logError logs err against op using the request-scoped logger found on ctx,
+// falling back to the repository's base logger. Paired with
+// logging.DedupingHandler, repeated op+error pairs raised during an outage
+// collapse into a single count summary instead of flooding logs.
+func (r *EmailRepository) logError(ctx context.Context, op string, err error) {
+    logging.FromContext(ctx, r.logger).Error("repository operation failed",
+        slog.String("op", op),
+        slog.String("error", err.Error()),
+    )
+}
+
 // Create inserts a new email record with proper sharding
 func (r *EmailRepository) Create(ctx context.Context, email *models.Email) error {
     timer := prometheus.NewTimer(r.metrics.duration.WithLabelValues("create"))
@@ -97,6 +164,7 @@ func (r *EmailRepository) Create(ctx context.Context, email *models.Email) error
 
     if err := email.Validate(); err != nil {
         r.metrics.errors.WithLabelValues("create").Inc()
+        r.logError(ctx, "create", err)
         return errors.Wrap(err, "invalid email data")
     }
 
@@ -107,6 +175,7 @@ func (r *EmailRepository) Create(ctx context.Context, email *models.Email) error
     tx, err := r.beginTx(ctx)
     if err != nil {
         r.metrics.errors.WithLabelValues("create").Inc()
+        r.logError(ctx, "create", err)
         return errors.Wrap(err, "failed to begin transaction")
     }
     defer tx.Rollback()
@@ -114,6 +183,7 @@ func (r *EmailRepository) Create(ctx context.Context, email *models.Email) error
     // Insert email record
     if err := r.insertEmail(ctx, tx, email, shardID); err != nil {
         r.metrics.errors.WithLabelValues("create").Inc()
+        r.logError(ctx, "create", err)
         return errors.Wrap(err, "failed to insert email")
     }
 
@@ -121,6 +191,7 @@ func (r *EmailRepository) Create(ctx context.Context, email *models.Email) error
     if len(email.Attachments) > 0 {
         if err := r.insertAttachments(ctx, tx, email.MessageID, email.Attachments); err != nil {
             r.metrics.errors.WithLabelValues("create").Inc()
+            r.logError(ctx, "create", err)
             return errors.Wrap(err, "failed to insert attachments")
         }
     }
@@ -128,6 +199,7 @@ func (r *EmailRepository) Create(ctx context.Context, email *models.Email) error
     // Commit transaction
     if err := tx.Commit(); err != nil {
         r.metrics.errors.WithLabelValues("create").Inc()
+        r.logError(ctx, "create", err)
         return errors.Wrap(err, "failed to commit transaction")
     }
 
@@ -171,12 +243,14 @@ func (r *EmailRepository) GetByID(ctx context.Context, messageID string, account
     }
     if err != nil {
         r.metrics.errors.WithLabelValues("get_by_id").Inc()
+        r.logError(ctx, "get_by_id", err)
         return nil, errors.Wrap(err, "failed to get email")
     }
 
     // Unmarshal metadata
     if err := json.Unmarshal(metadataJSON, &email.Metadata); err != nil {
         r.metrics.errors.WithLabelValues("get_by_id").Inc()
+        r.logError(ctx, "get_by_id", err)
         return nil, errors.Wrap(err, "failed to unmarshal metadata")
     }
 
@@ -184,6 +258,7 @@ func (r *EmailRepository) GetByID(ctx context.Context, messageID string, account
     attachments, err := r.getAttachments(ctx, messageID)
     if err != nil {
         r.metrics.errors.WithLabelValues("get_by_id").Inc()
+        r.logError(ctx, "get_by_id", err)
         return nil, errors.Wrap(err, "failed to get attachments")
     }
     email.Attachments = attachments
@@ -248,6 +323,436 @@ func (r *EmailRepository) insertEmail(ctx context.Context, tx *sql.Tx, email *mo
     return err
 }
 
+// BulkCreateResult summarizes the outcome of a BulkCreate call.
+type BulkCreateResult struct {
+    Processed int
+    Failed    int
+    Errors    []error
+}
+
+// BulkCreate fans emails out across the repository's bulk-write worker pool
+// and blocks until every email has been processed or ctx is cancelled.
+func (r *EmailRepository) BulkCreate(ctx context.Context, emails []*models.Email) (*BulkCreateResult, error) {
+    timer := prometheus.NewTimer(r.metrics.duration.WithLabelValues("bulk_create"))
+    defer timer.ObserveDuration()
+
+    workerCount := r.workerPool.WorkerCount
+    if workerCount <= 0 {
+        workerCount = defaultWorkerCount
+    }
+    queueCapacity := r.workerPool.QueueCapacity
+    if queueCapacity <= 0 {
+        queueCapacity = defaultQueueCapacity
+    }
+
+    pending := make(chan *models.Email, queueCapacity)
+    resultsCh := make(chan error, len(emails))
+
+    var wg sync.WaitGroup
+    for i := 0; i < workerCount; i++ {
+        wg.Add(1)
+        go r.bulkWorker(ctx, fmt.Sprintf("%d", i), pending, resultsCh, &wg)
+    }
+
+    var dispatched int64
+
+    go func() {
+        defer close(pending)
+        for _, email := range emails {
+            if _, loaded := r.inFlight.LoadOrStore(email.MessageID, struct{}{}); loaded {
+                // Already in flight from a prior submission; skip so an
+                // outer scheduler's retries don't double-submit.
+                atomic.AddInt64(&dispatched, 1)
+                resultsCh <- nil
+                continue
+            }
+            select {
+            case pending <- email:
+                atomic.AddInt64(&dispatched, 1)
+            case <-ctx.Done():
+                // Abandoned before reaching a worker: undo the LoadOrStore
+                // above so it isn't mistaken for still being in flight by a
+                // future BulkCreate call.
+                r.inFlight.Delete(email.MessageID)
+                return
+            }
+        }
+    }()
+
+    go func() {
+        wg.Wait()
+        close(resultsCh)
+    }()
+
+    result := &BulkCreateResult{}
+    for err := range resultsCh {
+        if err == nil {
+            continue
+        }
+        result.Failed++
+        result.Errors = append(result.Errors, err)
+    }
+    result.Processed = int(atomic.LoadInt64(&dispatched)) - result.Failed
+
+    if ctx.Err() != nil {
+        r.metrics.errors.WithLabelValues("bulk_create").Inc()
+        return result, errors.Wrap(ctx.Err(), "bulk create cancelled before completion")
+    }
+
+    return result, nil
+}
+
+// bulkWorker drains pending emails, grouping writes per shard into batches
+// of up to the configured batch size before committing each as a single
+// multi-VALUES INSERT inside its own serializable transaction.
+func (r *EmailRepository) bulkWorker(ctx context.Context, workerID string, pending <-chan *models.Email, results chan<- error, wg *sync.WaitGroup) {
+    defer wg.Done()
+
+    batchSize := r.workerPool.BatchSize
+    if batchSize <= 0 {
+        batchSize = defaultBatchSize
+    }
+
+    shardBatches := make(map[int][]*models.Email)
+
+    flush := func(shardID int) {
+        batch := shardBatches[shardID]
+        if len(batch) == 0 {
+            return
+        }
+        delete(shardBatches, shardID)
+        bulkBatchSize.Observe(float64(len(batch)))
+
+        err := r.commitShardBatch(ctx, shardID, batch)
+        if err != nil {
+            r.logError(ctx, "bulk_create", err)
+        }
+        for _, email := range batch {
+            r.inFlight.Delete(email.MessageID)
+            if err != nil {
+                bulkWorkerFailed.WithLabelValues(workerID).Inc()
+            } else {
+                bulkWorkerProcessed.WithLabelValues(workerID).Inc()
+            }
+            results <- err
+        }
+    }
+
+    for {
+        select {
+        case email, ok := <-pending:
+            if !ok {
+                // Channel drained; flush whatever remains before exiting so
+                // shutdown waits for in-flight batches.
+                for shardID := range shardBatches {
+                    flush(shardID)
+                }
+                return
+            }
+            shardID := r.shardMgr.GetShardID(email.AccountID)
+            shardBatches[shardID] = append(shardBatches[shardID], email)
+            if len(shardBatches[shardID]) >= batchSize {
+                flush(shardID)
+            }
+        case <-ctx.Done():
+            for shardID := range shardBatches {
+                flush(shardID)
+            }
+            return
+        }
+    }
+}
+
+// commitShardBatch writes a batch of emails destined for the same shard
+// inside a single serializable transaction, retrying on serialization
+// failures.
+func (r *EmailRepository) commitShardBatch(ctx context.Context, shardID int, batch []*models.Email) error {
+    tx, err := r.beginTx(ctx)
+    if err != nil {
+        return errors.Wrap(err, "failed to begin bulk transaction")
+    }
+    defer func() { tx.Rollback() }()
+
+    for attempt := 0; ; attempt++ {
+        err = r.insertEmailBatch(ctx, tx, batch, shardID)
+        if err == nil {
+            break
+        }
+        if !isRetryableError(err) || attempt >= maxRetries {
+            return errors.Wrap(err, "failed to insert email batch")
+        }
+        bulkWorkerRetried.WithLabelValues(fmt.Sprintf("shard-%d", shardID)).Inc()
+        time.Sleep(time.Duration(attempt+1) * retryBackoff)
+        // The failed statement aborted tx, so the retry runs in a new one.
+        tx.Rollback()
+        if tx, err = r.beginTx(ctx); err != nil {
+            return errors.Wrap(err, "failed to begin bulk transaction")
+        }
+    }
+
+    for _, email := range batch {
+        if len(email.Attachments) == 0 {
+            continue
+        }
+        if err := r.insertAttachments(ctx, tx, email.MessageID, email.Attachments); err != nil {
+            return errors.Wrap(err, "failed to insert attachments")
+        }
+    }
+
+    if err := tx.Commit(); err != nil {
+        return errors.Wrap(err, "failed to commit bulk transaction")
+    }
+    return nil
+}
+
+// insertEmailBatch inserts multiple email rows using a single multi-VALUES
+// INSERT statement.
+func (r *EmailRepository) insertEmailBatch(ctx context.Context, tx *sql.Tx, batch []*models.Email, shardID int) error {
+    const columnsPerRow = 19
+
+    placeholders := make([]string, 0, len(batch))
+    args := make([]interface{}, 0, len(batch)*columnsPerRow)
+
+    for i, email := range batch {
+        metadataJSON, err := json.Marshal(email.Metadata)
+        if err != nil {
+            return errors.Wrap(err, "failed to marshal metadata")
+        }
+
+        base := i * columnsPerRow
+        params := make([]string, columnsPerRow)
+        for j := 0; j < columnsPerRow; j++ {
+            params[j] = fmt.Sprintf("$%d", base+j+1)
+        }
+        placeholders = append(placeholders, "("+joinPlaceholders(params)+")")
+
+        args = append(args,
+            email.MessageID,
+            email.ThreadID,
+            email.ConversationID,
+            email.ThreadPosition,
+            email.AccountID,
+            email.Subject,
+            email.Content,
+            email.FromAddress,
+            pq.Array(email.ToAddresses),
+            pq.Array(email.CCAddresses),
+            pq.Array(email.BCCAddresses),
+            email.Priority,
+            email.Status,
+            pq.Array(email.Labels),
+            email.FolderPath,
+            email.SentAt,
+            email.ReceivedAt,
+            metadataJSON,
+            shardID,
+        )
+    }
+
+    query := `
+        INSERT INTO emails (
+            message_id, thread_id, conversation_id, thread_position,
+            account_id, subject, content, from_address,
+            to_addresses, cc_addresses, bcc_addresses,
+            priority, status, labels, folder_path,
+            sent_at, received_at, metadata, shard_id
+        ) VALUES ` + joinPlaceholders(placeholders)
+
+    _, err := tx.ExecContext(ctx, query, args...)
+    return err
+}
+
+// QueuedEmail pairs an email the mail dispatcher is ready to send with how
+// many times it has already failed, so the dispatcher can compute its next
+// backoff and retry-ceiling decision without a second round-trip query.
+type QueuedEmail struct {
+    Email        *models.Email
+    AttemptCount int
+}
+
+// GetQueuedBatch returns up to limit emails on shardID that are ready for
+// the mail dispatcher to send: status StatusQueued, either never attempted
+// or past their next_attempt_at backoff deadline, and with fewer than
+// maxRetries recorded failures. Results are ordered oldest-first so a
+// backed-up queue drains in submission order.
+func (r *EmailRepository) GetQueuedBatch(ctx context.Context, shardID int, limit int, maxRetries int) ([]*QueuedEmail, error) {
+    timer := prometheus.NewTimer(r.metrics.duration.WithLabelValues("get_queued_batch"))
+    defer timer.ObserveDuration()
+
+    rows, err := r.preparedStmts["get_queued_batch"].QueryContext(ctx, shardID, models.StatusQueued, limit, maxRetries)
+    if err != nil {
+        r.metrics.errors.WithLabelValues("get_queued_batch").Inc()
+        r.logError(ctx, "get_queued_batch", err)
+        return nil, errors.Wrap(err, "failed to query queued batch")
+    }
+    defer rows.Close()
+
+    var emails []*QueuedEmail
+    for rows.Next() {
+        var email models.Email
+        var metadataJSON []byte
+        var attemptCount int
+
+        if err := rows.Scan(
+            &email.MessageID,
+            &email.ThreadID,
+            &email.ConversationID,
+            &email.ThreadPosition,
+            &email.AccountID,
+            &email.Subject,
+            &email.Content,
+            &email.FromAddress,
+            pq.Array(&email.ToAddresses),
+            pq.Array(&email.CCAddresses),
+            pq.Array(&email.BCCAddresses),
+            &email.Priority,
+            &email.Status,
+            pq.Array(&email.Labels),
+            &email.FolderPath,
+            &email.SentAt,
+            &email.ReceivedAt,
+            &metadataJSON,
+            &attemptCount,
+        ); err != nil {
+            r.metrics.errors.WithLabelValues("get_queued_batch").Inc()
+            r.logError(ctx, "get_queued_batch", err)
+            return nil, errors.Wrap(err, "failed to scan queued email")
+        }
+
+        if err := json.Unmarshal(metadataJSON, &email.Metadata); err != nil {
+            r.metrics.errors.WithLabelValues("get_queued_batch").Inc()
+            r.logError(ctx, "get_queued_batch", err)
+            return nil, errors.Wrap(err, "failed to unmarshal metadata")
+        }
+
+        emails = append(emails, &QueuedEmail{Email: &email, AttemptCount: attemptCount})
+    }
+    if err := rows.Err(); err != nil {
+        r.metrics.errors.WithLabelValues("get_queued_batch").Inc()
+        r.logError(ctx, "get_queued_batch", err)
+        return nil, errors.Wrap(err, "failed to iterate queued batch")
+    }
+
+    return emails, nil
+}
+
+// MarkSent transitions an email to StatusSent and records the provider's
+// message ID and send timestamp, once a Transport has accepted it.
+func (r *EmailRepository) MarkSent(ctx context.Context, messageID string, accountID string, providerID string, sentAt time.Time) error {
+    timer := prometheus.NewTimer(r.metrics.duration.WithLabelValues("mark_sent"))
+    defer timer.ObserveDuration()
+
+    shardID := r.shardMgr.GetShardID(accountID)
+
+    metadataPatch, err := json.Marshal(map[string]string{"provider_message_id": providerID})
+    if err != nil {
+        return errors.Wrap(err, "failed to marshal provider message id")
+    }
+
+    _, err = r.preparedStmts["mark_sent"].ExecContext(ctx, models.StatusSent, sentAt, metadataPatch, messageID, shardID)
+    if err != nil {
+        r.metrics.errors.WithLabelValues("mark_sent").Inc()
+        r.logError(ctx, "mark_sent", err)
+        return errors.Wrap(err, "failed to mark email sent")
+    }
+
+    return nil
+}
+
+// RecordSendFailure upserts the retry bookkeeping row for messageID after a
+// failed send attempt, incrementing attempt_count and scheduling
+// nextAttemptAt per the dispatcher's exponential backoff policy.
+func (r *EmailRepository) RecordSendFailure(ctx context.Context, messageID string, sendErr error, nextAttemptAt time.Time) error {
+    timer := prometheus.NewTimer(r.metrics.duration.WithLabelValues("record_send_failure"))
+    defer timer.ObserveDuration()
+
+    _, err := r.preparedStmts["upsert_send_attempt"].ExecContext(ctx, messageID, nextAttemptAt, sendErr.Error())
+    if err != nil {
+        r.metrics.errors.WithLabelValues("record_send_failure").Inc()
+        r.logError(ctx, "record_send_failure", err)
+        return errors.Wrap(err, "failed to record send failure")
+    }
+
+    return nil
+}
+
+// EmailsSince returns every email sent or received at or after since,
+// across every account and shard, for internal/export's daily compliance
+// archive run. Unlike GetByID/GetQueuedBatch it is not scoped to a single
+// shard_id: the emails table lives on one physical database regardless of
+// how many logical shards ShardManager divides accounts into, so a single
+// query already covers every account.
+func (r *EmailRepository) EmailsSince(ctx context.Context, since time.Time) ([]models.Email, error) {
+    timer := prometheus.NewTimer(r.metrics.duration.WithLabelValues("emails_since"))
+    defer timer.ObserveDuration()
+
+    rows, err := r.preparedStmts["emails_since"].QueryContext(ctx, since)
+    if err != nil {
+        r.metrics.errors.WithLabelValues("emails_since").Inc()
+        r.logError(ctx, "emails_since", err)
+        return nil, errors.Wrap(err, "failed to query emails since timestamp")
+    }
+    defer rows.Close()
+
+    var emails []models.Email
+    for rows.Next() {
+        var email models.Email
+        var metadataJSON []byte
+
+        if err := rows.Scan(
+            &email.MessageID,
+            &email.ThreadID,
+            &email.ConversationID,
+            &email.ThreadPosition,
+            &email.AccountID,
+            &email.Subject,
+            &email.Content,
+            &email.FromAddress,
+            pq.Array(&email.ToAddresses),
+            pq.Array(&email.CCAddresses),
+            pq.Array(&email.BCCAddresses),
+            &email.Priority,
+            &email.Status,
+            pq.Array(&email.Labels),
+            &email.FolderPath,
+            &email.SentAt,
+            &email.ReceivedAt,
+            &metadataJSON,
+        ); err != nil {
+            r.metrics.errors.WithLabelValues("emails_since").Inc()
+            r.logError(ctx, "emails_since", err)
+            return nil, errors.Wrap(err, "failed to scan email")
+        }
+
+        if err := json.Unmarshal(metadataJSON, &email.Metadata); err != nil {
+            r.metrics.errors.WithLabelValues("emails_since").Inc()
+            r.logError(ctx, "emails_since", err)
+            return nil, errors.Wrap(err, "failed to unmarshal metadata")
+        }
+
+        emails = append(emails, email)
+    }
+    if err := rows.Err(); err != nil {
+        r.metrics.errors.WithLabelValues("emails_since").Inc()
+        r.logError(ctx, "emails_since", err)
+        return nil, errors.Wrap(err, "failed to iterate emails since timestamp")
+    }
+
+    return emails, nil
+}
+
+// joinPlaceholders joins SQL placeholder fragments with commas.
+func joinPlaceholders(parts []string) string {
+    joined := ""
+    for i, p := range parts {
+        if i > 0 {
+            joined += ", "
+        }
+        joined += p
+    }
+    return joined
+}
+
 // prepareStatements prepares all SQL statements
 func prepareStatements(db *sql.DB) (map[string]*sql.Stmt, error) {
     statements := map[string]string{
@@ -267,6 +772,40 @@ func prepareStatements(db *sql.DB) (map[string]*sql.Stmt, error) {
                    sent_at, received_at, metadata
             FROM emails
             WHERE message_id = $1 AND shard_id = $2`,
+        "get_queued_batch": `
+            SELECT e.message_id, e.thread_id, e.conversation_id, e.thread_position,
+                   e.account_id, e.subject, e.content, e.from_address,
+                   e.to_addresses, e.cc_addresses, e.bcc_addresses,
+                   e.priority, e.status, e.labels, e.folder_path,
+                   e.sent_at, e.received_at, e.metadata,
+                   COALESCE(a.attempt_count, 0)
+            FROM emails e
+            LEFT JOIN email_send_attempts a ON a.message_id = e.message_id
+            WHERE e.shard_id = $1 AND e.status = $2
+              AND (a.next_attempt_at IS NULL OR a.next_attempt_at <= now())
+              AND (a.attempt_count IS NULL OR a.attempt_count < $4)
+            ORDER BY e.received_at ASC
+            LIMIT $3`,
+        "mark_sent": `
+            UPDATE emails
+            SET status = $1, sent_at = $2, metadata = metadata || $3::jsonb
+            WHERE message_id = $4 AND shard_id = $5`,
+        "upsert_send_attempt": `
+            INSERT INTO email_send_attempts (message_id, attempt_count, next_attempt_at, last_error)
+            VALUES ($1, 1, $2, $3)
+            ON CONFLICT (message_id) DO UPDATE
+            SET attempt_count = email_send_attempts.attempt_count + 1,
+                next_attempt_at = EXCLUDED.next_attempt_at,
+                last_error = EXCLUDED.last_error`,
+        "emails_since": `
+            SELECT message_id, thread_id, conversation_id, thread_position,
+                   account_id, subject, content, from_address,
+                   to_addresses, cc_addresses, bcc_addresses,
+                   priority, status, labels, folder_path,
+                   sent_at, received_at, metadata
+            FROM emails
+            WHERE sent_at >= $1 OR received_at >= $1
+            ORDER BY received_at ASC`,
     }
 
     prepared := make(map[string]*sql.Stmt)
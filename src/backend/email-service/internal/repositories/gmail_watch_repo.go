@@ -0,0 +1,92 @@
+package repositories
+
+import (
+    "context"
+    "database/sql"
+    "log/slog"
+    "time"
+
+    "github.com/pkg/errors" // v0.9.1
+    "github.com/prometheus/client_golang/prometheus" // v1.16.0
+    "github.com/prometheus/client_golang/prometheus/promauto"
+
+    "github.com/email-management-platform/backend/email-service/internal/logging"
+)
+
+var gmailWatchOperationErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+    Name: "gmail_watch_repository_operation_errors_total",
+    Help: "Total number of gmail watch repository operation errors",
+}, []string{"operation"})
+
+// GmailWatch is a mailbox's current users.watch registration, as tracked in
+// the gmail_watches table so the gmailwatch subsystem can renew it without
+// re-watching every mailbox on every restart.
+type GmailWatch struct {
+    AccountID string
+    HistoryID string
+    Topic     string
+    ExpiresAt time.Time
+}
+
+// GmailWatchRepository persists gmail_watches rows. Unlike EmailRepository
+// this isn't sharded: it's a small control-plane table, not email volume.
+type GmailWatchRepository struct {
+    db     *sql.DB
+    logger *slog.Logger
+}
+
+// NewGmailWatchRepository creates a new GmailWatchRepository. logger may be
+// nil, in which case slog.Default() is used.
+func NewGmailWatchRepository(db *sql.DB, logger *slog.Logger) (*GmailWatchRepository, error) {
+    if db == nil {
+        return nil, errors.New("database connection is required")
+    }
+    if logger == nil {
+        logger = slog.Default()
+    }
+    return &GmailWatchRepository{db: db, logger: logger}, nil
+}
+
+// Save upserts the watch registration for accountID.
+func (r *GmailWatchRepository) Save(ctx context.Context, watch *GmailWatch) error {
+    _, err := r.db.ExecContext(ctx, `
+        INSERT INTO gmail_watches (account_id, history_id, topic, expires_at, updated_at)
+        VALUES ($1, $2, $3, $4, now())
+        ON CONFLICT (account_id) DO UPDATE SET
+            history_id = EXCLUDED.history_id,
+            topic = EXCLUDED.topic,
+            expires_at = EXCLUDED.expires_at,
+            updated_at = now()
+    `, watch.AccountID, watch.HistoryID, watch.Topic, watch.ExpiresAt)
+    if err != nil {
+        gmailWatchOperationErrors.WithLabelValues("save").Inc()
+        r.logError(ctx, "save", err)
+        return errors.Wrap(err, "failed to save gmail watch")
+    }
+    return nil
+}
+
+// Get returns the current watch registration for accountID, or nil if none
+// exists yet.
+func (r *GmailWatchRepository) Get(ctx context.Context, accountID string) (*GmailWatch, error) {
+    watch := &GmailWatch{AccountID: accountID}
+    err := r.db.QueryRowContext(ctx, `
+        SELECT history_id, topic, expires_at FROM gmail_watches WHERE account_id = $1
+    `, accountID).Scan(&watch.HistoryID, &watch.Topic, &watch.ExpiresAt)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    }
+    if err != nil {
+        gmailWatchOperationErrors.WithLabelValues("get").Inc()
+        r.logError(ctx, "get", err)
+        return nil, errors.Wrap(err, "failed to get gmail watch")
+    }
+    return watch, nil
+}
+
+func (r *GmailWatchRepository) logError(ctx context.Context, op string, err error) {
+    logging.FromContext(ctx, r.logger).Error("gmail watch repository operation failed",
+        slog.String("op", op),
+        slog.String("error", err.Error()),
+    )
+}
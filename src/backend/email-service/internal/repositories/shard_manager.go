@@ -0,0 +1,46 @@
+package repositories
+
+import (
+    "hash/fnv"
+
+    "github.com/pkg/errors" // v0.9.1
+
+    "github.com/email-management-platform/backend/email-service/internal/config"
+)
+
+// ShardManager resolves which database shard owns a given account, using a
+// stable hash of the account ID over the configured shard set.
+type ShardManager struct {
+    shardIDs []int
+}
+
+// NewShardManager builds a ShardManager from the given shard configuration.
+func NewShardManager(cfg *config.ShardConfig) (*ShardManager, error) {
+    if cfg == nil || len(cfg.Shards) == 0 {
+        return nil, errors.New("at least one shard must be configured")
+    }
+
+    ids := make([]int, len(cfg.Shards))
+    for i, shard := range cfg.Shards {
+        ids[i] = shard.ShardID
+    }
+
+    return &ShardManager{shardIDs: ids}, nil
+}
+
+// GetShardID deterministically maps accountID to one of the configured
+// shard IDs.
+func (m *ShardManager) GetShardID(accountID string) int {
+    h := fnv.New32a()
+    _, _ = h.Write([]byte(accountID))
+    idx := int(h.Sum32()) % len(m.shardIDs)
+    if idx < 0 {
+        idx += len(m.shardIDs)
+    }
+    return m.shardIDs[idx]
+}
+
+// ShardIDs returns every shard ID this manager knows about.
+func (m *ShardManager) ShardIDs() []int {
+    return m.shardIDs
+}
@@ -0,0 +1,62 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws" // v1.22.2
+	awsconfig "github.com/aws/aws-sdk-go-v2/config" // v1.22.2
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager" // v1.25.2
+)
+
+// AWSConfig configures the AWS Secrets Manager provider.
+type AWSConfig struct {
+	Region string `mapstructure:"region"`
+}
+
+// AWSProvider resolves secrets from AWS Secrets Manager. Each secret is
+// expected to be stored as a JSON object so that a single AWS secret can
+// back multiple keys (e.g. one "email-service/database" secret holding
+// both a username and a password).
+type AWSProvider struct {
+	client *secretsmanager.Client
+}
+
+// AWSProvider implements SecretProvider.
+var _ SecretProvider = (*AWSProvider)(nil)
+
+// NewAWSProvider builds a client using the default AWS credential chain,
+// scoped to cfg.Region. It fails fast if the region can't be resolved.
+func NewAWSProvider(cfg AWSConfig) (*AWSProvider, error) {
+	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(cfg.Region))
+	if err != nil {
+		return nil, fmt.Errorf("aws secrets manager: failed to load AWS config: %w", err)
+	}
+	return &AWSProvider{client: secretsmanager.NewFromConfig(awsCfg)}, nil
+}
+
+// GetSecret fetches the secret named path, parses it as a JSON object, and
+// returns the value stored under key.
+func (p *AWSProvider) GetSecret(ctx context.Context, path string, key string) (string, error) {
+	out, err := p.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(path),
+	})
+	if err != nil {
+		return "", fmt.Errorf("aws secrets manager: failed to fetch secret %q: %w", path, err)
+	}
+	if out.SecretString == nil {
+		return "", fmt.Errorf("aws secrets manager: secret %q has no string value", path)
+	}
+
+	var fields map[string]string
+	if err := json.Unmarshal([]byte(*out.SecretString), &fields); err != nil {
+		return "", fmt.Errorf("aws secrets manager: secret %q is not a JSON object: %w", path, err)
+	}
+
+	value, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("aws secrets manager: secret %q has no key %q", path, key)
+	}
+	return value, nil
+}
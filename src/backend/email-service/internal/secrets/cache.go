@@ -0,0 +1,132 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTTL is used when a caller doesn't supply one.
+const DefaultTTL = time.Minute * 5
+
+// cacheEntry holds a cached secret value and when it was fetched.
+type cacheEntry struct {
+	value     string
+	fetchedAt time.Time
+}
+
+// Cache wraps a SecretProvider with a TTL cache and an optional background
+// refresh loop, so a secret rotated in the backend propagates to a running
+// process without every call paying the backend's latency and without
+// requiring a restart.
+type Cache struct {
+	provider SecretProvider
+	ttl      time.Duration
+
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+}
+
+// NewCache wraps provider with a TTL cache. A zero ttl uses DefaultTTL.
+func NewCache(provider SecretProvider, ttl time.Duration) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Cache{
+		provider: provider,
+		ttl:      ttl,
+		entries:  make(map[string]cacheEntry),
+	}
+}
+
+// Get returns the cached value for path#key if it's younger than the
+// cache's TTL, otherwise fetches and caches a fresh value.
+func (c *Cache) Get(ctx context.Context, path string, key string) (string, error) {
+	cacheKey := path + "#" + key
+
+	c.mu.RLock()
+	entry, ok := c.entries[cacheKey]
+	c.mu.RUnlock()
+	if ok && time.Since(entry.fetchedAt) < c.ttl {
+		return entry.value, nil
+	}
+
+	return c.refresh(ctx, path, key, cacheKey)
+}
+
+// refresh fetches path#key from the backend unconditionally and updates
+// the cache entry.
+func (c *Cache) refresh(ctx context.Context, path string, key string, cacheKey string) (string, error) {
+	value, err := c.provider.GetSecret(ctx, path, key)
+	if err != nil {
+		return "", fmt.Errorf("secrets: failed to fetch %s: %w", cacheKey, err)
+	}
+
+	c.mu.Lock()
+	c.entries[cacheKey] = cacheEntry{value: value, fetchedAt: time.Now()}
+	c.mu.Unlock()
+
+	return value, nil
+}
+
+// StartPeriodicRefresh re-fetches every currently cached entry from the
+// backend every interval, so a rotated secret propagates even if nothing
+// is actively reading it at the moment it expires. It blocks until ctx is
+// cancelled.
+func (c *Cache) StartPeriodicRefresh(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = c.ttl
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.refreshAll(ctx)
+		}
+	}
+}
+
+// refreshAll re-fetches every entry currently in the cache.
+func (c *Cache) refreshAll(ctx context.Context) {
+	c.mu.RLock()
+	keys := make([]string, 0, len(c.entries))
+	for cacheKey := range c.entries {
+		keys = append(keys, cacheKey)
+	}
+	c.mu.RUnlock()
+
+	for _, cacheKey := range keys {
+		// Split at the first "#", as ParseRef does: a path never contains
+		// "#", while a key may.
+		path, key, found := strings.Cut(cacheKey, "#")
+		if !found {
+			continue
+		}
+		if _, err := c.refresh(ctx, path, key, cacheKey); err != nil {
+			// The entry keeps its last good value and fetch time, so Get
+			// serves it until its TTL runs out; the next tick will retry.
+			continue
+		}
+	}
+}
+
+// cutLast splits s on the last occurrence of sep.
+func cutLast(s string, sep string) (before string, after string, found bool) {
+	idx := -1
+	for i := 0; i+len(sep) <= len(s); i++ {
+		if s[i:i+len(sep)] == sep {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return "", "", false
+	}
+	return s[:idx], s[idx+len(sep):], true
+}
@@ -0,0 +1,46 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnvProvider resolves secret references against environment variables,
+// keyed as EMAIL_SERVICE_SECRET_<PATH>_<KEY> (uppercased, non-alphanumeric
+// runs collapsed to underscores). It's the default provider and exists so
+// local development and CI don't need a real secret backend.
+type EnvProvider struct{}
+
+// EnvProvider implements SecretProvider.
+var _ SecretProvider = (*EnvProvider)(nil)
+
+// NewEnvProvider creates an EnvProvider.
+func NewEnvProvider() *EnvProvider {
+	return &EnvProvider{}
+}
+
+// GetSecret implements SecretProvider.
+func (p *EnvProvider) GetSecret(ctx context.Context, path string, key string) (string, error) {
+	envName := "EMAIL_SERVICE_SECRET_" + envSafe(path) + "_" + envSafe(key)
+	value, ok := os.LookupEnv(envName)
+	if !ok {
+		return "", fmt.Errorf("env: environment variable %s is not set", envName)
+	}
+	return value, nil
+}
+
+// envSafe uppercases s and replaces any character that isn't alphanumeric
+// with an underscore, so arbitrary secret paths map to valid env var names.
+func envSafe(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1" // v1.11.4
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+)
+
+// GCPConfig configures the GCP Secret Manager provider.
+type GCPConfig struct {
+	ProjectID string `mapstructure:"project_id"`
+}
+
+// GCPProvider resolves secrets from GCP Secret Manager. Like AWSProvider,
+// each secret is expected to hold a JSON object so one GCP secret can back
+// multiple keys.
+type GCPProvider struct {
+	client    *secretmanager.Client
+	projectID string
+}
+
+// GCPProvider implements SecretProvider.
+var _ SecretProvider = (*GCPProvider)(nil)
+
+// NewGCPProvider builds a client using application default credentials.
+// It fails fast if a client can't be constructed (e.g. no credentials
+// available in the environment).
+func NewGCPProvider(cfg GCPConfig) (*GCPProvider, error) {
+	if cfg.ProjectID == "" {
+		return nil, fmt.Errorf("gcp secret manager: project_id is required")
+	}
+
+	client, err := secretmanager.NewClient(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("gcp secret manager: failed to create client: %w", err)
+	}
+
+	return &GCPProvider{client: client, projectID: cfg.ProjectID}, nil
+}
+
+// GetSecret fetches the "latest" version of the secret named path, parses
+// it as a JSON object, and returns the value stored under key.
+func (p *GCPProvider) GetSecret(ctx context.Context, path string, key string) (string, error) {
+	name := fmt.Sprintf("projects/%s/secrets/%s/versions/latest", p.projectID, path)
+
+	result, err := p.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("gcp secret manager: failed to access secret %q: %w", path, err)
+	}
+
+	var fields map[string]string
+	if err := json.Unmarshal(result.Payload.Data, &fields); err != nil {
+		return "", fmt.Errorf("gcp secret manager: secret %q is not a JSON object: %w", path, err)
+	}
+
+	value, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("gcp secret manager: secret %q has no key %q", path, key)
+	}
+	return value, nil
+}
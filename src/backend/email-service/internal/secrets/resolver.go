@@ -0,0 +1,61 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// refPrefix marks a configuration value as a secret reference rather than
+// a literal value: "secret://path#key".
+const refPrefix = "secret://"
+
+// Ref is a parsed "secret://path#key" reference.
+type Ref struct {
+	Path string
+	Key  string
+}
+
+// ParseRef parses value as a secret reference. ok is false if value
+// doesn't use the secret:// scheme, in which case callers should treat
+// value as a literal.
+func ParseRef(value string) (ref Ref, ok bool) {
+	if !strings.HasPrefix(value, refPrefix) {
+		return Ref{}, false
+	}
+
+	rest := strings.TrimPrefix(value, refPrefix)
+	path, key, found := strings.Cut(rest, "#")
+	if !found || path == "" || key == "" {
+		return Ref{}, false
+	}
+
+	return Ref{Path: path, Key: key}, true
+}
+
+// Resolver resolves secret:// references through a Cache, leaving plain
+// values untouched. It's the entry point config.LoadConfig uses to
+// transparently resolve any field that may hold a reference.
+type Resolver struct {
+	cache *Cache
+}
+
+// NewResolver creates a Resolver backed by cache.
+func NewResolver(cache *Cache) *Resolver {
+	return &Resolver{cache: cache}
+}
+
+// Resolve returns value unchanged if it isn't a secret:// reference;
+// otherwise it fetches (and caches) the referenced secret.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	ref, ok := ParseRef(value)
+	if !ok {
+		return value, nil
+	}
+
+	resolved, err := r.cache.Get(ctx, ref.Path, ref.Key)
+	if err != nil {
+		return "", fmt.Errorf("secrets: failed to resolve %q: %w", value, err)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,102 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	vaultapi "github.com/hashicorp/vault/api" // v1.10.0
+)
+
+// VaultConfig configures AppRole authentication against a HashiCorp Vault
+// cluster. AppRole is used (rather than a static token) so the service can
+// rotate its credential without an operator ever touching a long-lived
+// token.
+type VaultConfig struct {
+	Address   string `mapstructure:"address"`
+	Namespace string `mapstructure:"namespace"`
+	RoleID    string `mapstructure:"role_id"`
+	SecretID  string `mapstructure:"secret_id"`
+	// MountPath is the KV v2 mount secrets are read from, e.g. "secret".
+	MountPath string `mapstructure:"mount_path"`
+}
+
+// VaultProvider resolves secrets from a Vault KV v2 mount, authenticating
+// via AppRole on construction.
+type VaultProvider struct {
+	cfg    VaultConfig
+	client *vaultapi.Client
+}
+
+// VaultProvider implements SecretProvider.
+var _ SecretProvider = (*VaultProvider)(nil)
+
+// NewVaultProvider logs in to Vault using AppRole and returns a provider
+// ready to serve GetSecret calls. It fails fast, per the secret manager
+// requirement that a misconfigured or unreachable backend surface at
+// startup rather than on first use.
+func NewVaultProvider(cfg VaultConfig) (*VaultProvider, error) {
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("vault: address is required")
+	}
+	if cfg.RoleID == "" || cfg.SecretID == "" {
+		return nil, fmt.Errorf("vault: role_id and secret_id are required for AppRole auth")
+	}
+
+	clientCfg := vaultapi.DefaultConfig()
+	clientCfg.Address = cfg.Address
+	client, err := vaultapi.NewClient(clientCfg)
+	if err != nil {
+		return nil, fmt.Errorf("vault: failed to create client: %w", err)
+	}
+	if cfg.Namespace != "" {
+		client.SetNamespace(cfg.Namespace)
+	}
+
+	secret, err := client.Logical().Write("auth/approle/login", map[string]interface{}{
+		"role_id":   cfg.RoleID,
+		"secret_id": cfg.SecretID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("vault: approle login failed: %w", err)
+	}
+	if secret == nil || secret.Auth == nil {
+		return nil, fmt.Errorf("vault: approle login returned no auth information")
+	}
+	client.SetToken(secret.Auth.ClientToken)
+
+	mountPath := cfg.MountPath
+	if mountPath == "" {
+		mountPath = "secret"
+	}
+	cfg.MountPath = mountPath
+
+	return &VaultProvider{cfg: cfg, client: client}, nil
+}
+
+// GetSecret reads the KV v2 secret at path and returns the value stored
+// under key.
+func (p *VaultProvider) GetSecret(ctx context.Context, path string, key string) (string, error) {
+	secret, err := p.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/data/%s", p.cfg.MountPath, path))
+	if err != nil {
+		return "", fmt.Errorf("vault: failed to read secret %q: %w", path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return "", fmt.Errorf("vault: secret %q not found", path)
+	}
+
+	data, ok := secret.Data["data"].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("vault: secret %q has unexpected shape", path)
+	}
+
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("vault: secret %q has no key %q", path, key)
+	}
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("vault: secret %q key %q is not a string", path, key)
+	}
+
+	return str, nil
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+    "context"
+    "hash/fnv"
+    "sync"
+
+    "github.com/bits-and-blooms/bloom/v3" // v3.6.0
+    "github.com/prometheus/client_golang/prometheus" // v1.17.0
+    "github.com/prometheus/client_golang/prometheus/promauto"
+    "golang.org/x/time/rate" // v0.3.0
+
+    "github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// Metrics for ProcessBatch. batchShardSize observes, per call, how many
+// emails landed on each shard, so an operator can tell a hot-account
+// imbalance from an actual processing slowdown.
+var (
+    batchShardSize = promauto.NewHistogram(prometheus.HistogramOpts{
+        Name:    "email_service_batch_shard_size",
+        Help:    "Number of emails dispatched to a single ProcessBatch shard",
+        Buckets: prometheus.ExponentialBuckets(1, 2, 10),
+    })
+
+    batchInflight = promauto.NewGauge(prometheus.GaugeOpts{
+        Name: "email_service_batch_inflight",
+        Help: "Number of emails currently being processed by ProcessBatch workers",
+    })
+
+    batchSkippedDuplicate = promauto.NewCounter(prometheus.CounterOpts{
+        Name: "email_service_batch_skipped_duplicate_total",
+        Help: "Total number of emails ProcessBatch skipped because their MessageID was already dispatched in the current run",
+    })
+)
+
+const (
+    defaultBatchShards           = 8
+    defaultBatchFilterCapacity   = 100000
+    defaultBatchFilterFalsePositive = 0.01
+)
+
+// BatchOptions configures ProcessBatch. A zero value runs with
+// defaultBatchShards workers and a duplicate filter sized for
+// defaultBatchFilterCapacity (100,000) emails per invocation.
+type BatchOptions struct {
+    // Shards is the number of worker goroutines emails are hashed across
+    // by AccountID. 0 uses defaultBatchShards.
+    Shards int
+    // FilterCapacity sizes the bloom filter used to guard against
+    // reprocessing a MessageID already dispatched in this run. 0 uses
+    // defaultBatchFilterCapacity.
+    FilterCapacity uint
+}
+
+// BatchItemStatus describes the outcome ProcessBatch recorded for a single
+// email.
+type BatchItemStatus int
+
+const (
+    // BatchItemSucceeded means the email was processed successfully.
+    BatchItemSucceeded BatchItemStatus = iota
+    // BatchItemFailed means ProcessEmail returned an error for this email.
+    BatchItemFailed
+    // BatchItemSkippedDuplicate means the email's MessageID was already
+    // dispatched earlier in this same ProcessBatch call.
+    BatchItemSkippedDuplicate
+)
+
+// BatchItemResult is the outcome ProcessBatch recorded for a single email.
+type BatchItemResult struct {
+    MessageID string
+    Status    BatchItemStatus
+    Err       error
+}
+
+// BatchResult is the aggregate outcome of a ProcessBatch call.
+type BatchResult struct {
+    Items      []BatchItemResult
+    Succeeded  int
+    Failed     int
+    Skipped    int
+}
+
+// ProcessBatch processes emails concurrently across a fixed pool of
+// worker goroutines, sharding by AccountID (hash mod Shards) so a single
+// account's emails are always handled by the same worker and never race
+// each other. Each worker paces its calls with its own token bucket
+// copied from the service's global rate limiter; ProcessEmail itself
+// still waits on the global limiter as well. Emails whose MessageID was
+// already dispatched earlier in this call are skipped rather than
+// reprocessed, so an email listed twice in emails (e.g. from overlapping
+// queries of a "pending" table) is processed once. Each call starts with
+// an empty guard, so an email passed to an earlier call is processed
+// again.
+func (s *EmailService) ProcessBatch(ctx context.Context, emails []*models.Email, opts BatchOptions) *BatchResult {
+    shardCount := opts.Shards
+    if shardCount <= 0 {
+        shardCount = defaultBatchShards
+    }
+
+    filterCapacity := opts.FilterCapacity
+    if filterCapacity == 0 {
+        filterCapacity = defaultBatchFilterCapacity
+    }
+
+    dispatched := &dispatchGuard{
+        filter: bloom.NewWithEstimates(filterCapacity, defaultBatchFilterFalsePositive),
+    }
+
+    shards := make([][]*models.Email, shardCount)
+    for _, email := range emails {
+        idx := shardIndex(email.AccountID, shardCount)
+        shards[idx] = append(shards[idx], email)
+    }
+
+    results := make([][]BatchItemResult, shardCount)
+    var wg sync.WaitGroup
+
+    for i, shard := range shards {
+        if len(shard) == 0 {
+            continue
+        }
+        batchShardSize.Observe(float64(len(shard)))
+
+        wg.Add(1)
+        go func(i int, shard []*models.Email) {
+            defer wg.Done()
+            results[i] = s.processShard(ctx, shard, dispatched)
+        }(i, shard)
+    }
+
+    wg.Wait()
+
+    result := &BatchResult{}
+    for _, shardResults := range results {
+        for _, item := range shardResults {
+            result.Items = append(result.Items, item)
+            switch item.Status {
+            case BatchItemSucceeded:
+                result.Succeeded++
+            case BatchItemFailed:
+                result.Failed++
+            case BatchItemSkippedDuplicate:
+                result.Skipped++
+            }
+        }
+    }
+
+    return result
+}
+
+// processShard runs one worker's slice of emails through ProcessEmail
+// sequentially, pacing them with a rate limiter copied from the service's
+// global one (ProcessEmail also waits on the global limiter itself).
+func (s *EmailService) processShard(ctx context.Context, shard []*models.Email, dispatched *dispatchGuard) []BatchItemResult {
+    limiter := rate.NewLimiter(s.rateLimiter.Limit(), s.rateLimiter.Burst())
+    items := make([]BatchItemResult, 0, len(shard))
+
+    for _, email := range shard {
+        if dispatched.alreadyDispatched(email.MessageID) {
+            batchSkippedDuplicate.Inc()
+            items = append(items, BatchItemResult{MessageID: email.MessageID, Status: BatchItemSkippedDuplicate})
+            continue
+        }
+
+        if err := limiter.Wait(ctx); err != nil {
+            items = append(items, BatchItemResult{MessageID: email.MessageID, Status: BatchItemFailed, Err: err})
+            continue
+        }
+
+        batchInflight.Inc()
+        err := s.ProcessEmail(ctx, email)
+        batchInflight.Dec()
+
+        if err != nil {
+            items = append(items, BatchItemResult{MessageID: email.MessageID, Status: BatchItemFailed, Err: err})
+            continue
+        }
+
+        items = append(items, BatchItemResult{MessageID: email.MessageID, Status: BatchItemSucceeded})
+    }
+
+    return items
+}
+
+// dispatchGuard tracks which MessageIDs have already been dispatched
+// during the current ProcessBatch call, using a bloom filter so the guard
+// stays cheap even for very large batches. A bloom filter can false-
+// positive (incorrectly reporting a new MessageID as a duplicate), which
+// is an acceptable trade for this guard's purpose: an email that's
+// wrongly skipped here is simply retried on the caller's next batch,
+// whereas double-processing never is.
+type dispatchGuard struct {
+    mu     sync.Mutex
+    filter *bloom.BloomFilter
+}
+
+func (g *dispatchGuard) alreadyDispatched(messageID string) bool {
+    g.mu.Lock()
+    defer g.mu.Unlock()
+
+    key := []byte(messageID)
+    if g.filter.Test(key) {
+        return true
+    }
+    g.filter.Add(key)
+    return false
+}
+
+// shardIndex deterministically maps accountID to one of shardCount
+// worker shards.
+func shardIndex(accountID string, shardCount int) int {
+    h := fnv.New32a()
+    _, _ = h.Write([]byte(accountID))
+    idx := int(h.Sum32()) % shardCount
+    if idx < 0 {
+        idx += shardCount
+    }
+    return idx
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+    "context"
+    "fmt"
+    "os"
+    "strings"
+
+    "github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// singleEmailPrimaryStore adapts one already-fetched *models.Email into a
+// cache.PrimaryStore. cache.Store's GetRFC822/GetBody contract only
+// identifies a blob by its bare messageID/attachmentID, with no account
+// context to resolve it from scratch, so GetRawBody/GetAttachmentBody
+// resolve the email first and hand the cache a primary scoped to that one
+// lookup rather than a general-purpose store.
+type singleEmailPrimaryStore struct {
+    email *models.Email
+}
+
+// FetchRFC822 renders email's structured fields into a raw RFC 822
+// message, the same shape smtp.EmailSender builds for outbound mail.
+func (p *singleEmailPrimaryStore) FetchRFC822(ctx context.Context, messageID string) ([]byte, error) {
+    return renderRFC822(p.email), nil
+}
+
+// FetchAttachmentBody reads the attachment's body from the path it was
+// stored at when the email was received.
+func (p *singleEmailPrimaryStore) FetchAttachmentBody(ctx context.Context, attachmentID string) ([]byte, error) {
+    for _, a := range p.email.Attachments {
+        if a.AttachmentID != attachmentID {
+            continue
+        }
+        body, err := os.ReadFile(a.StoragePath)
+        if err != nil {
+            return nil, fmt.Errorf("services: failed to read attachment %q: %w", attachmentID, err)
+        }
+        return body, nil
+    }
+    return nil, fmt.Errorf("services: attachment %q not found on email %q", attachmentID, p.email.MessageID)
+}
+
+// renderRFC822 builds a minimal raw RFC 822 message from email's
+// structured fields, for callers (GetRawBody, the body cache's primary
+// fallback) that need the same bytes a mail client would receive rather
+// than the structured *models.Email.
+func renderRFC822(email *models.Email) []byte {
+    var buf strings.Builder
+    fmt.Fprintf(&buf, "From: %s\r\n", email.FromAddress)
+    fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(email.ToAddresses, ", "))
+    if len(email.CCAddresses) > 0 {
+        fmt.Fprintf(&buf, "Cc: %s\r\n", strings.Join(email.CCAddresses, ", "))
+    }
+    if email.MessageID != "" {
+        fmt.Fprintf(&buf, "Message-ID: %s\r\n", email.MessageID)
+    }
+    fmt.Fprintf(&buf, "Subject: %s\r\n", email.Subject)
+    for k, v := range email.Headers {
+        fmt.Fprintf(&buf, "%s: %s\r\n", k, v)
+    }
+    buf.WriteString("MIME-Version: 1.0\r\n")
+    buf.WriteString("Content-Type: text/html; charset=utf-8\r\n\r\n")
+    buf.WriteString(email.Content)
+    return []byte(buf.String())
+}
+
+// GetRawBody returns messageID's full raw RFC 822 body, preferring the
+// service's body cache and rendering (then caching) it fresh on a miss.
+func (s *EmailService) GetRawBody(ctx context.Context, messageID, accountID string) ([]byte, error) {
+    email, err := s.GetEmailByID(ctx, messageID, accountID)
+    if err != nil {
+        return nil, err
+    }
+    if email == nil {
+        return nil, fmt.Errorf("services: email %q not found", messageID)
+    }
+
+    return email.GetRFC822(ctx, s.bodyCache, &singleEmailPrimaryStore{email: email})
+}
+
+// GetAttachmentBody returns attachmentID's raw body for the email it
+// belongs to, preferring the service's body cache and reading it fresh
+// from its storage path on a miss.
+func (s *EmailService) GetAttachmentBody(ctx context.Context, messageID, accountID, attachmentID string) ([]byte, error) {
+    email, err := s.GetEmailByID(ctx, messageID, accountID)
+    if err != nil {
+        return nil, err
+    }
+    if email == nil {
+        return nil, fmt.Errorf("services: email %q not found", messageID)
+    }
+
+    for i := range email.Attachments {
+        if email.Attachments[i].AttachmentID != attachmentID {
+            continue
+        }
+        return email.Attachments[i].GetBody(ctx, email.AccountID, s.bodyCache, &singleEmailPrimaryStore{email: email})
+    }
+    return nil, fmt.Errorf("services: attachment %q not found on email %q", attachmentID, messageID)
+}
@@ -0,0 +1,426 @@
+package services
+
+import (
+    "bytes"
+    "compress/gzip"
+    "context"
+    "crypto/aes"
+    "crypto/cipher"
+    "crypto/rand"
+    "encoding/binary"
+    "encoding/gob"
+    "fmt"
+    "io"
+    "os"
+    "time"
+
+    "github.com/klauspost/compress/zstd" // v1.17.0
+    "github.com/patrickmn/go-cache" // v2.1.0
+    "github.com/prometheus/client_golang/prometheus" // v1.17.0
+    "github.com/prometheus/client_golang/prometheus/promauto"
+    "go.etcd.io/bbolt" // v1.3.7
+    "golang.org/x/sys/unix" // v0.13.0
+
+    "github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// emailsBucket stores messageID -> serialized *models.Email. indexBucket
+// stores a write-order index (storedAt-prefixed key -> messageID) so
+// evictLowDisk can find the oldest entries without scanning emailsBucket.
+var (
+    emailsBucketName = []byte("emails")
+    indexBucketName  = []byte("index")
+)
+
+// Compression selects how L2 cache entries are compressed on disk.
+type Compression int
+
+const (
+    // CompressionNone stores the gob-encoded email as-is.
+    CompressionNone Compression = iota
+    // CompressionGzip compresses with compress/gzip.
+    CompressionGzip
+    // CompressionZstd compresses with zstd, trading a small CPU cost for
+    // noticeably smaller on-disk bodies than gzip.
+    CompressionZstd
+)
+
+// CacheOptions configures EmailService's two-tier cache: an in-memory L1
+// (unconditional) and an optional disk-backed L2. A zero value disables
+// L2 and keeps the original in-memory-only behavior.
+type CacheOptions struct {
+    // DiskPath is the bbolt database file backing L2. L2 is disabled when
+    // this is empty.
+    DiskPath string
+    // MaxBytes caps the L2 database's on-disk size; 0 means unbounded
+    // (subject only to MinFreeBytes).
+    MaxBytes int64
+    // MinFreeBytes is the minimum free space the volume holding DiskPath
+    // must retain; once free space falls below this, the oldest L2
+    // entries are evicted before a new one is written.
+    MinFreeBytes int64
+    // Compression selects how entries are compressed before being
+    // written to L2.
+    Compression Compression
+    // EncryptionKey, if set, must be 16, 24, or 32 bytes (AES-128/192/256)
+    // and is used to encrypt entries with AES-GCM before they're written
+    // to L2.
+    EncryptionKey []byte
+}
+
+// cacheMetrics counts L1/L2 cache outcomes, labeled by result so an
+// operator can see at a glance how much traffic is served from memory
+// versus disk versus falling through to the repository.
+var cacheResultsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+    Name: "email_service_cache_results_total",
+    Help: "Total number of email cache lookups by result (l1_hit, l2_hit, miss)",
+}, []string{"result"})
+
+var cacheEvictionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+    Name: "email_service_cache_evictions_total",
+    Help: "Total number of L2 cache entries evicted to reclaim disk space",
+}, []string{"reason"})
+
+// tieredCache is EmailService's L1 (in-memory go-cache) plus an optional
+// L2 (disk-backed bbolt database) cache for models.Email, following the
+// on-disk message cache pattern IMAP bridge tools use so full bodies
+// don't thrash memory while still surviving a process restart.
+type tieredCache struct {
+    l1   *cache.Cache
+    l2   *bbolt.DB
+    opts CacheOptions
+    gcm  cipher.AEAD
+}
+
+// newTieredCache builds a tieredCache. L2 is only opened when
+// opts.DiskPath is set.
+func newTieredCache(opts CacheOptions) (*tieredCache, error) {
+    tc := &tieredCache{
+        l1:   cache.New(cacheTTL, cacheTTL*2),
+        opts: opts,
+    }
+
+    if len(opts.EncryptionKey) > 0 {
+        block, err := aes.NewCipher(opts.EncryptionKey)
+        if err != nil {
+            return nil, fmt.Errorf("cache: invalid encryption key: %w", err)
+        }
+        gcm, err := cipher.NewGCM(block)
+        if err != nil {
+            return nil, fmt.Errorf("cache: failed to initialize AES-GCM: %w", err)
+        }
+        tc.gcm = gcm
+    }
+
+    if opts.DiskPath == "" {
+        return tc, nil
+    }
+
+    db, err := bbolt.Open(opts.DiskPath, 0o600, &bbolt.Options{Timeout: time.Second * 5})
+    if err != nil {
+        return nil, fmt.Errorf("cache: failed to open L2 database %q: %w", opts.DiskPath, err)
+    }
+
+    if err := db.Update(func(tx *bbolt.Tx) error {
+        if _, err := tx.CreateBucketIfNotExists(emailsBucketName); err != nil {
+            return err
+        }
+        _, err := tx.CreateBucketIfNotExists(indexBucketName)
+        return err
+    }); err != nil {
+        db.Close()
+        return nil, fmt.Errorf("cache: failed to initialize L2 buckets: %w", err)
+    }
+
+    tc.l2 = db
+    return tc, nil
+}
+
+// get checks L1, then L2 (promoting an L2 hit back into L1 so the next
+// lookup is served from memory), and returns (nil, false) on a full miss.
+func (tc *tieredCache) get(messageID string) (*models.Email, bool) {
+    if cached, found := tc.l1.Get(messageID); found {
+        cacheResultsTotal.WithLabelValues("l1_hit").Inc()
+        return cached.(*models.Email), true
+    }
+
+    if tc.l2 == nil {
+        cacheResultsTotal.WithLabelValues("miss").Inc()
+        return nil, false
+    }
+
+    email, err := tc.getFromDisk(messageID)
+    if err != nil || email == nil {
+        cacheResultsTotal.WithLabelValues("miss").Inc()
+        return nil, false
+    }
+
+    cacheResultsTotal.WithLabelValues("l2_hit").Inc()
+    tc.l1.Set(messageID, email, cache.DefaultExpiration)
+    return email, true
+}
+
+// set stores email in L1 and, if configured, persists it to L2 after
+// evicting enough of L2's oldest entries to stay under the configured
+// capacity thresholds.
+func (tc *tieredCache) set(email *models.Email) {
+    tc.l1.Set(email.MessageID, email, cache.DefaultExpiration)
+
+    if tc.l2 == nil {
+        return
+    }
+
+    if err := tc.putToDisk(email); err != nil {
+        // L2 is a best-effort tier; a write failure just means this
+        // entry won't survive a restart, not a request failure.
+        return
+    }
+}
+
+// warmup primes L1 from every entry currently in L2, so a freshly started
+// process doesn't pay L2's (slower) lookup cost for data it already has
+// on disk.
+func (tc *tieredCache) warmup(ctx context.Context) error {
+    if tc.l2 == nil {
+        return nil
+    }
+
+    return tc.l2.View(func(tx *bbolt.Tx) error {
+        return tx.Bucket(emailsBucketName).ForEach(func(k, v []byte) error {
+            select {
+            case <-ctx.Done():
+                return ctx.Err()
+            default:
+            }
+
+            email, err := tc.decode(v)
+            if err != nil {
+                return nil // skip a corrupt entry rather than failing warmup entirely
+            }
+            tc.l1.Set(string(k), email, cache.DefaultExpiration)
+            return nil
+        })
+    })
+}
+
+// close releases the L2 database handle, if one is open.
+func (tc *tieredCache) close() error {
+    if tc.l2 == nil {
+        return nil
+    }
+    return tc.l2.Close()
+}
+
+func (tc *tieredCache) getFromDisk(messageID string) (*models.Email, error) {
+    var raw []byte
+    err := tc.l2.View(func(tx *bbolt.Tx) error {
+        v := tx.Bucket(emailsBucketName).Get([]byte(messageID))
+        if v != nil {
+            raw = append([]byte(nil), v...)
+        }
+        return nil
+    })
+    if err != nil || raw == nil {
+        return nil, err
+    }
+    return tc.decode(raw)
+}
+
+func (tc *tieredCache) putToDisk(email *models.Email) error {
+    if err := tc.evictForCapacity(); err != nil {
+        return err
+    }
+
+    encoded, err := tc.encode(email)
+    if err != nil {
+        return err
+    }
+
+    return tc.l2.Update(func(tx *bbolt.Tx) error {
+        if err := tx.Bucket(emailsBucketName).Put([]byte(email.MessageID), encoded); err != nil {
+            return err
+        }
+        return tx.Bucket(indexBucketName).Put(indexKey(email.MessageID), []byte(email.MessageID))
+    })
+}
+
+// evictForCapacity removes the oldest L2 entries (by insertion order)
+// until the database file is under MaxBytes and the volume holding it has
+// at least MinFreeBytes free.
+func (tc *tieredCache) evictForCapacity() error {
+    for {
+        overCap := tc.opts.MaxBytes > 0 && tc.dbSize() > tc.opts.MaxBytes
+        underFree := tc.opts.MinFreeBytes > 0 && tc.freeDiskBytes() < tc.opts.MinFreeBytes
+        if !overCap && !underFree {
+            return nil
+        }
+
+        evicted, err := tc.evictOldest()
+        if err != nil {
+            return err
+        }
+        if !evicted {
+            // Nothing left to evict; stop rather than looping forever.
+            return nil
+        }
+
+        reason := "max_bytes"
+        if underFree {
+            reason = "min_free_bytes"
+        }
+        cacheEvictionsTotal.WithLabelValues(reason).Inc()
+    }
+}
+
+func (tc *tieredCache) evictOldest() (bool, error) {
+    var evicted bool
+    err := tc.l2.Update(func(tx *bbolt.Tx) error {
+        index := tx.Bucket(indexBucketName)
+        cursor := index.Cursor()
+        k, v := cursor.First()
+        if k == nil {
+            return nil
+        }
+
+        if err := tx.Bucket(emailsBucketName).Delete(v); err != nil {
+            return err
+        }
+        if err := index.Delete(k); err != nil {
+            return err
+        }
+        evicted = true
+        return nil
+    })
+    return evicted, err
+}
+
+// dbSize returns the L2 database file's current size on disk, or 0 if it
+// can't be determined.
+func (tc *tieredCache) dbSize() int64 {
+    info, err := os.Stat(tc.l2.Path())
+    if err != nil {
+        return 0
+    }
+    return info.Size()
+}
+
+// freeDiskBytes returns the free space available on the volume holding
+// the L2 database file.
+func (tc *tieredCache) freeDiskBytes() int64 {
+    var stat unix.Statfs_t
+    if err := unix.Statfs(tc.opts.DiskPath, &stat); err != nil {
+        return -1
+    }
+    return int64(stat.Bavail) * int64(stat.Bsize)
+}
+
+// indexKey builds a write-order key: a nanosecond timestamp so
+// bbolt's byte-sorted keys iterate oldest-first, followed by messageID to
+// keep keys unique even for entries written in the same nanosecond.
+func indexKey(messageID string) []byte {
+    buf := make([]byte, 8+len(messageID))
+    binary.BigEndian.PutUint64(buf, uint64(time.Now().UnixNano()))
+    copy(buf[8:], messageID)
+    return buf
+}
+
+func (tc *tieredCache) encode(email *models.Email) ([]byte, error) {
+    var buf bytes.Buffer
+    if err := gob.NewEncoder(&buf).Encode(email); err != nil {
+        return nil, fmt.Errorf("cache: failed to encode email: %w", err)
+    }
+
+    compressed, err := tc.compress(buf.Bytes())
+    if err != nil {
+        return nil, err
+    }
+
+    if tc.gcm == nil {
+        return compressed, nil
+    }
+    return tc.encrypt(compressed)
+}
+
+func (tc *tieredCache) decode(raw []byte) (*models.Email, error) {
+    plain := raw
+    if tc.gcm != nil {
+        decrypted, err := tc.decrypt(raw)
+        if err != nil {
+            return nil, err
+        }
+        plain = decrypted
+    }
+
+    decompressed, err := tc.decompress(plain)
+    if err != nil {
+        return nil, err
+    }
+
+    var email models.Email
+    if err := gob.NewDecoder(bytes.NewReader(decompressed)).Decode(&email); err != nil {
+        return nil, fmt.Errorf("cache: failed to decode email: %w", err)
+    }
+    return &email, nil
+}
+
+func (tc *tieredCache) compress(data []byte) ([]byte, error) {
+    switch tc.opts.Compression {
+    case CompressionGzip:
+        var buf bytes.Buffer
+        w := gzip.NewWriter(&buf)
+        if _, err := w.Write(data); err != nil {
+            return nil, fmt.Errorf("cache: gzip compression failed: %w", err)
+        }
+        if err := w.Close(); err != nil {
+            return nil, fmt.Errorf("cache: gzip compression failed: %w", err)
+        }
+        return buf.Bytes(), nil
+    case CompressionZstd:
+        enc, err := zstd.NewWriter(nil)
+        if err != nil {
+            return nil, fmt.Errorf("cache: failed to initialize zstd encoder: %w", err)
+        }
+        defer enc.Close()
+        return enc.EncodeAll(data, nil), nil
+    default:
+        return data, nil
+    }
+}
+
+func (tc *tieredCache) decompress(data []byte) ([]byte, error) {
+    switch tc.opts.Compression {
+    case CompressionGzip:
+        r, err := gzip.NewReader(bytes.NewReader(data))
+        if err != nil {
+            return nil, fmt.Errorf("cache: gzip decompression failed: %w", err)
+        }
+        defer r.Close()
+        return io.ReadAll(r)
+    case CompressionZstd:
+        dec, err := zstd.NewReader(nil)
+        if err != nil {
+            return nil, fmt.Errorf("cache: failed to initialize zstd decoder: %w", err)
+        }
+        defer dec.Close()
+        return dec.DecodeAll(data, nil)
+    default:
+        return data, nil
+    }
+}
+
+func (tc *tieredCache) encrypt(plain []byte) ([]byte, error) {
+    nonce := make([]byte, tc.gcm.NonceSize())
+    if _, err := rand.Read(nonce); err != nil {
+        return nil, fmt.Errorf("cache: failed to generate nonce: %w", err)
+    }
+    return tc.gcm.Seal(nonce, nonce, plain, nil), nil
+}
+
+func (tc *tieredCache) decrypt(sealed []byte) ([]byte, error) {
+    nonceSize := tc.gcm.NonceSize()
+    if len(sealed) < nonceSize {
+        return nil, fmt.Errorf("cache: encrypted entry is too short")
+    }
+    nonce, ciphertext := sealed[:nonceSize], sealed[nonceSize:]
+    return tc.gcm.Open(nil, nonce, ciphertext, nil)
+}
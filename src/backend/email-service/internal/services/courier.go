@@ -0,0 +1,251 @@
+package services
+
+import (
+    "bytes"
+    "context"
+    "crypto/tls"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "net/smtp"
+    "strings"
+    "time"
+
+    "github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// Courier channel labels, used on the courier_dispatch_* metrics.
+const (
+    ChannelSMTP = "smtp"
+    ChannelSMS  = "sms"
+)
+
+// Courier delivers a processed email over a concrete outbound channel
+// (SMTP, SMS, ...). EmailService calls Dispatch from processEmailWithRetry
+// once an email has been durably persisted via the repository, so a
+// Courier only needs to worry about the single send attempt; retries are
+// handled by the caller's retry loop.
+type Courier interface {
+    // Dispatch sends email over the courier's channel.
+    Dispatch(ctx context.Context, email *models.Email) error
+    // Channel identifies this courier on metrics (ChannelSMTP, ChannelSMS, ...).
+    Channel() string
+}
+
+// SMTPCourierConfig configures SMTPCourier.
+type SMTPCourierConfig struct {
+    Host        string
+    Port        int
+    Username    string
+    Password    string
+    FromAddress string
+    FromName    string
+    Headers     map[string]string
+    UseSTARTTLS bool
+}
+
+// SMTPCourier delivers mail through a single SMTP relay, optionally
+// upgrading the connection with STARTTLS before authenticating.
+type SMTPCourier struct {
+    cfg SMTPCourierConfig
+}
+
+// SMTPCourier implements Courier.
+var _ Courier = (*SMTPCourier)(nil)
+
+// NewSMTPCourier creates a courier that relays through cfg.Host:cfg.Port.
+func NewSMTPCourier(cfg SMTPCourierConfig) *SMTPCourier {
+    return &SMTPCourier{cfg: cfg}
+}
+
+// Channel identifies this courier as "smtp".
+func (c *SMTPCourier) Channel() string {
+    return ChannelSMTP
+}
+
+// Dispatch builds a minimal RFC 5322 message, attaching any configured
+// custom headers, and relays it through the SMTP server.
+func (c *SMTPCourier) Dispatch(ctx context.Context, email *models.Email) error {
+    addr := fmt.Sprintf("%s:%d", c.cfg.Host, c.cfg.Port)
+
+    from := c.cfg.FromAddress
+    if from == "" {
+        from = email.FromAddress
+    }
+
+    recipients := make([]string, 0, len(email.ToAddresses)+len(email.CCAddresses)+len(email.BCCAddresses))
+    recipients = append(recipients, email.ToAddresses...)
+    recipients = append(recipients, email.CCAddresses...)
+    recipients = append(recipients, email.BCCAddresses...)
+
+    message := c.buildMessage(email, from)
+
+    var auth smtp.Auth
+    if c.cfg.Username != "" {
+        auth = smtp.PlainAuth("", c.cfg.Username, c.cfg.Password, c.cfg.Host)
+    }
+
+    if c.cfg.UseSTARTTLS {
+        if err := c.sendWithSTARTTLS(addr, auth, from, recipients, message); err != nil {
+            return fmt.Errorf("smtp courier: failed to send message: %w", err)
+        }
+        return nil
+    }
+
+    if err := smtp.SendMail(addr, auth, from, recipients, message); err != nil {
+        return fmt.Errorf("smtp courier: failed to send message: %w", err)
+    }
+    return nil
+}
+
+// buildMessage renders email as a minimal RFC 5322 message with any
+// configured custom headers appended.
+func (c *SMTPCourier) buildMessage(email *models.Email, from string) []byte {
+    var body strings.Builder
+    fmt.Fprintf(&body, "From: %s\r\n", formatAddress(c.cfg.FromName, from))
+    fmt.Fprintf(&body, "To: %s\r\n", strings.Join(email.ToAddresses, ", "))
+    if len(email.CCAddresses) > 0 {
+        fmt.Fprintf(&body, "Cc: %s\r\n", strings.Join(email.CCAddresses, ", "))
+    }
+    fmt.Fprintf(&body, "Subject: %s\r\n", email.Subject)
+    for k, v := range c.cfg.Headers {
+        fmt.Fprintf(&body, "%s: %s\r\n", k, v)
+    }
+    body.WriteString("\r\n")
+    body.WriteString(email.Content)
+    return []byte(body.String())
+}
+
+// sendWithSTARTTLS dials addr directly so the connection can be upgraded
+// with STARTTLS before AUTH is attempted, which smtp.SendMail has no way
+// to request.
+func (c *SMTPCourier) sendWithSTARTTLS(addr string, auth smtp.Auth, from string, recipients []string, message []byte) error {
+    client, err := smtp.Dial(addr)
+    if err != nil {
+        return fmt.Errorf("failed to dial %s: %w", addr, err)
+    }
+    defer client.Close()
+
+    if ok, _ := client.Extension("STARTTLS"); ok {
+        if err := client.StartTLS(&tls.Config{ServerName: c.cfg.Host}); err != nil {
+            return fmt.Errorf("starttls failed: %w", err)
+        }
+    }
+
+    if auth != nil {
+        if err := client.Auth(auth); err != nil {
+            return fmt.Errorf("auth failed: %w", err)
+        }
+    }
+
+    if err := client.Mail(from); err != nil {
+        return err
+    }
+    for _, recipient := range recipients {
+        if err := client.Rcpt(recipient); err != nil {
+            return err
+        }
+    }
+
+    w, err := client.Data()
+    if err != nil {
+        return err
+    }
+    if _, err := w.Write(message); err != nil {
+        return err
+    }
+    if err := w.Close(); err != nil {
+        return err
+    }
+
+    return client.Quit()
+}
+
+// formatAddress renders a display name plus address as
+// `"name" <addr>`, or bare addr when name is empty.
+func formatAddress(name, addr string) string {
+    if name == "" {
+        return addr
+    }
+    return fmt.Sprintf("%q <%s>", name, addr)
+}
+
+// SMSCourierConfig configures SMSCourier.
+type SMSCourierConfig struct {
+    // Endpoint is the HTTPS URL SMSCourier POSTs a Twilio-compatible
+    // {From, To, Body} JSON payload to.
+    Endpoint  string
+    From      string
+    AuthToken string
+    Timeout   time.Duration
+}
+
+// SMSCourier delivers mail as an SMS by POSTing a JSON-templated,
+// Twilio-compatible payload to a configurable HTTPS endpoint.
+type SMSCourier struct {
+    cfg        SMSCourierConfig
+    httpClient *http.Client
+}
+
+// SMSCourier implements Courier.
+var _ Courier = (*SMSCourier)(nil)
+
+const defaultSMSCourierTimeout = time.Second * 10
+
+// NewSMSCourier creates a courier that POSTs to cfg.Endpoint.
+func NewSMSCourier(cfg SMSCourierConfig) *SMSCourier {
+    timeout := cfg.Timeout
+    if timeout <= 0 {
+        timeout = defaultSMSCourierTimeout
+    }
+    return &SMSCourier{cfg: cfg, httpClient: &http.Client{Timeout: timeout}}
+}
+
+// Channel identifies this courier as "sms".
+func (c *SMSCourier) Channel() string {
+    return ChannelSMS
+}
+
+// smsPayload is a Twilio-compatible outbound message payload.
+type smsPayload struct {
+    From string `json:"From"`
+    To   string `json:"To"`
+    Body string `json:"Body"`
+}
+
+// Dispatch POSTs email's first recipient and content as an SMS payload to
+// the configured endpoint.
+func (c *SMSCourier) Dispatch(ctx context.Context, email *models.Email) error {
+    if len(email.ToAddresses) == 0 {
+        return fmt.Errorf("sms courier: email has no recipients")
+    }
+
+    encoded, err := json.Marshal(smsPayload{
+        From: c.cfg.From,
+        To:   email.ToAddresses[0],
+        Body: email.Content,
+    })
+    if err != nil {
+        return fmt.Errorf("sms courier: failed to encode payload: %w", err)
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.Endpoint, bytes.NewReader(encoded))
+    if err != nil {
+        return fmt.Errorf("sms courier: failed to build request: %w", err)
+    }
+    req.Header.Set("Content-Type", "application/json")
+    if c.cfg.AuthToken != "" {
+        req.Header.Set("Authorization", "Bearer "+c.cfg.AuthToken)
+    }
+
+    resp, err := c.httpClient.Do(req)
+    if err != nil {
+        return fmt.Errorf("sms courier: request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode >= http.StatusBadRequest {
+        return fmt.Errorf("sms courier: request rejected with status %d", resp.StatusCode)
+    }
+    return nil
+}
@@ -0,0 +1,334 @@
+package services
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus" // v1.17.0
+    "github.com/prometheus/client_golang/prometheus/promauto"
+    "github.com/sony/gobreaker" // v0.5.0
+    "golang.org/x/time/rate" // v0.3.0
+
+    "github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// Metrics for DeliveryQueue.
+var (
+    deliveryQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+        Name: "email_service_delivery_queue_depth",
+        Help: "Number of items currently waiting in the delivery queue",
+    })
+
+    deliveryInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+        Name: "email_service_delivery_inflight",
+        Help: "Number of deliveries currently in flight, by destination domain",
+    }, []string{"domain"})
+
+    deliveryDropsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "email_service_delivery_drops_total",
+        Help: "Total number of deliveries dropped without being attempted",
+    }, []string{"reason"})
+)
+
+const (
+    defaultDeliveryWorkers       = 4
+    defaultDeliveryQueueSize     = 1000
+    defaultDeliveryRatePerDomain = 10
+    defaultDeliveryBurstPerDomain = 10
+
+    // badHostConsecutiveFailures marks a destination domain "bad" after
+    // this many consecutive delivery failures.
+    badHostConsecutiveFailures = 5
+    // badHostProbeInterval is how long a bad domain is skipped before the
+    // next delivery attempt is let through as a half-open probe.
+    badHostProbeInterval = time.Minute
+)
+
+// DeliveryQueueOptions configures a DeliveryQueue. A zero value runs a
+// small worker pool suitable for moderate outbound volume.
+type DeliveryQueueOptions struct {
+    // Workers is the number of goroutines draining the queue. 0 uses
+    // defaultDeliveryWorkers.
+    Workers int
+    // QueueSize bounds how many items may be waiting at once; Enqueue
+    // fails once it's full rather than blocking the caller. 0 uses
+    // defaultDeliveryQueueSize.
+    QueueSize int
+    // RatePerDomain and BurstPerDomain size each destination domain's
+    // token bucket. 0 uses the package defaults.
+    RatePerDomain  float64
+    BurstPerDomain int
+    // Sink observes every email successfully delivered by a worker. A nil
+    // Sink disables recording, the same as EmailService's own WithSink.
+    Sink Sink
+}
+
+// deliveryItem is one queued delivery attempt. cancel is wired into ctx,
+// so Cancel/CancelByRecipient can abort an attempt whether it's still
+// queued or already in flight at the courier.
+type deliveryItem struct {
+    email  *models.Email
+    domain string
+    ctx    context.Context
+    cancel context.CancelFunc
+}
+
+// DeliveryQueue fans queued emails out to a fixed pool of workers, each
+// honoring a per-destination-domain rate limit and a per-domain "bad
+// host" circuit breaker so a single unreachable destination can't stall
+// delivery to every other domain. It mirrors the structure of federated
+// delivery pools (e.g. ActivityPub outboxes), where the same target-host
+// isolation problem shows up.
+type DeliveryQueue struct {
+    courier Courier
+    opts    DeliveryQueueOptions
+    items   chan *deliveryItem
+
+    mu         sync.Mutex
+    byMessage  map[string]*deliveryItem
+    byDomain   map[string][]*deliveryItem
+    limiters   map[string]*rate.Limiter
+    badHosts   map[string]*gobreaker.CircuitBreaker
+
+    stopCh chan struct{}
+    wg     sync.WaitGroup
+}
+
+// NewDeliveryQueue creates a DeliveryQueue that delivers through courier.
+// Start must be called before Enqueue will make progress.
+func NewDeliveryQueue(courier Courier, opts DeliveryQueueOptions) *DeliveryQueue {
+    if opts.Workers <= 0 {
+        opts.Workers = defaultDeliveryWorkers
+    }
+    if opts.QueueSize <= 0 {
+        opts.QueueSize = defaultDeliveryQueueSize
+    }
+    if opts.RatePerDomain <= 0 {
+        opts.RatePerDomain = defaultDeliveryRatePerDomain
+    }
+    if opts.BurstPerDomain <= 0 {
+        opts.BurstPerDomain = defaultDeliveryBurstPerDomain
+    }
+    if opts.Sink == nil {
+        opts.Sink = noopSink{}
+    }
+
+    return &DeliveryQueue{
+        courier:   courier,
+        opts:      opts,
+        items:     make(chan *deliveryItem, opts.QueueSize),
+        byMessage: make(map[string]*deliveryItem),
+        byDomain:  make(map[string][]*deliveryItem),
+        limiters:  make(map[string]*rate.Limiter),
+        badHosts:  make(map[string]*gobreaker.CircuitBreaker),
+        stopCh:    make(chan struct{}),
+    }
+}
+
+// Start launches the worker pool. Workers run until Stop is called.
+func (q *DeliveryQueue) Start() {
+    for i := 0; i < q.opts.Workers; i++ {
+        q.wg.Add(1)
+        go q.runWorker()
+    }
+}
+
+// Stop drains any in-flight work and stops every worker. It does not wait
+// for already-enqueued items to finish delivering.
+func (q *DeliveryQueue) Stop() {
+    close(q.stopCh)
+    q.wg.Wait()
+}
+
+// Enqueue queues email for delivery. It returns an error without queuing
+// if the queue is full.
+func (q *DeliveryQueue) Enqueue(email *models.Email) error {
+    domain := destinationDomain(email)
+    ctx, cancel := context.WithCancel(context.Background())
+    item := &deliveryItem{email: email, domain: domain, ctx: ctx, cancel: cancel}
+
+    select {
+    case q.items <- item:
+    default:
+        cancel()
+        deliveryDropsTotal.WithLabelValues("queue_full").Inc()
+        return fmt.Errorf("delivery queue: queue is full")
+    }
+
+    q.mu.Lock()
+    q.byMessage[email.MessageID] = item
+    q.byDomain[domain] = append(q.byDomain[domain], item)
+    q.mu.Unlock()
+
+    deliveryQueueDepth.Inc()
+    return nil
+}
+
+// Cancel aborts the delivery for messageID, whether it's still queued or
+// already in flight at the courier. It returns false if no such delivery
+// is tracked (already taken and finished by a worker, or never enqueued).
+// A cancelled delivery stays tracked until a worker takes it off the
+// queue, so calling Cancel again before then also returns true.
+func (q *DeliveryQueue) Cancel(messageID string) bool {
+    q.mu.Lock()
+    item, ok := q.byMessage[messageID]
+    q.mu.Unlock()
+    if !ok {
+        return false
+    }
+
+    item.cancel()
+    return true
+}
+
+// CancelByRecipient aborts every queued or in-flight delivery addressed to
+// target's destination domain, returning how many deliveries it cancelled.
+func (q *DeliveryQueue) CancelByRecipient(target string) int {
+    domain := domainOf(target)
+
+    q.mu.Lock()
+    items := q.byDomain[domain]
+    q.mu.Unlock()
+
+    for _, item := range items {
+        item.cancel()
+    }
+    return len(items)
+}
+
+func (q *DeliveryQueue) runWorker() {
+    defer q.wg.Done()
+
+    for {
+        select {
+        case <-q.stopCh:
+            return
+        case item, ok := <-q.items:
+            if !ok {
+                return
+            }
+            deliveryQueueDepth.Dec()
+            q.deliver(item)
+        }
+    }
+}
+
+// deliver attempts a single delivery, consulting the destination domain's
+// bad-host breaker and rate limiter before handing off to the courier,
+// and always untracks item afterward so Cancel/CancelByRecipient stop
+// matching it.
+func (q *DeliveryQueue) deliver(item *deliveryItem) {
+    defer q.untrack(item)
+
+    select {
+    case <-item.ctx.Done():
+        deliveryDropsTotal.WithLabelValues("cancelled").Inc()
+        return
+    default:
+    }
+
+    breaker := q.breakerFor(item.domain)
+    limiter := q.limiterFor(item.domain)
+
+    if err := limiter.Wait(item.ctx); err != nil {
+        deliveryDropsTotal.WithLabelValues("cancelled").Inc()
+        return
+    }
+
+    channel := q.courier.Channel()
+    timer := prometheus.NewTimer(courierDispatchDuration.WithLabelValues(channel))
+    deliveryInFlight.WithLabelValues(item.domain).Inc()
+    _, err := breaker.Execute(func() (interface{}, error) {
+        return nil, q.courier.Dispatch(item.ctx, item.email)
+    })
+    deliveryInFlight.WithLabelValues(item.domain).Dec()
+    timer.ObserveDuration()
+
+    if err != nil {
+        courierDispatchErrors.WithLabelValues(channel).Inc()
+        if err == gobreaker.ErrOpenState {
+            deliveryDropsTotal.WithLabelValues("bad_host").Inc()
+        } else {
+            deliveryDropsTotal.WithLabelValues("delivery_failed").Inc()
+        }
+        return
+    }
+
+    courierDispatchTotal.WithLabelValues(channel).Inc()
+    q.opts.Sink.Record(&SentMessage{MessageID: item.email.MessageID, Channel: channel, Email: item.email})
+}
+
+func (q *DeliveryQueue) untrack(item *deliveryItem) {
+    q.mu.Lock()
+    defer q.mu.Unlock()
+
+    if q.byMessage[item.email.MessageID] == item {
+        delete(q.byMessage, item.email.MessageID)
+    }
+
+    peers := q.byDomain[item.domain]
+    for i, peer := range peers {
+        if peer == item {
+            q.byDomain[item.domain] = append(peers[:i], peers[i+1:]...)
+            break
+        }
+    }
+}
+
+// limiterFor returns domain's token bucket, creating one on first use.
+func (q *DeliveryQueue) limiterFor(domain string) *rate.Limiter {
+    q.mu.Lock()
+    defer q.mu.Unlock()
+
+    limiter, ok := q.limiters[domain]
+    if !ok {
+        limiter = rate.NewLimiter(rate.Limit(q.opts.RatePerDomain), q.opts.BurstPerDomain)
+        q.limiters[domain] = limiter
+    }
+    return limiter
+}
+
+// breakerFor returns domain's bad-host breaker, creating one on first
+// use. It trips after badHostConsecutiveFailures consecutive failures and
+// allows one probe request through every badHostProbeInterval thereafter,
+// the same half-open recovery gobreaker gives the service's other
+// breakers.
+func (q *DeliveryQueue) breakerFor(domain string) *gobreaker.CircuitBreaker {
+    q.mu.Lock()
+    defer q.mu.Unlock()
+
+    breaker, ok := q.badHosts[domain]
+    if !ok {
+        breaker = gobreaker.NewCircuitBreaker(gobreaker.Settings{
+            Name:        "delivery-" + domain,
+            MaxRequests: 1,
+            Timeout:     badHostProbeInterval,
+            ReadyToTrip: func(counts gobreaker.Counts) bool {
+                return counts.ConsecutiveFailures >= badHostConsecutiveFailures
+            },
+        })
+        q.badHosts[domain] = breaker
+    }
+    return breaker
+}
+
+// destinationDomain returns the domain of email's first recipient, the
+// destination CancelByRecipient/per-domain rate limiting key on.
+func destinationDomain(email *models.Email) string {
+    if len(email.ToAddresses) == 0 {
+        return ""
+    }
+    return domainOf(email.ToAddresses[0])
+}
+
+// domainOf extracts the domain portion of an address, returning the
+// whole address if it doesn't contain an "@".
+func domainOf(addr string) string {
+    if i := strings.LastIndex(addr, "@"); i >= 0 {
+        return strings.ToLower(addr[i+1:])
+    }
+    return strings.ToLower(addr)
+}
@@ -5,7 +5,7 @@ package services
 import (
     "context"
     "fmt"
-    "sync"
+    "sync/atomic"
     "time"
 
     "github.com/pkg/errors" // v0.9.1
@@ -13,12 +13,17 @@ import (
     "github.com/prometheus/client_golang/prometheus/promauto"
     "golang.org/x/time/rate" // v0.3.0
     "github.com/sony/gobreaker" // v0.5.0
-    "github.com/patrickmn/go-cache" // v2.1.0
 
+    "github.com/email-management-platform/backend/email-service/internal/cache"
+    "github.com/email-management-platform/backend/email-service/internal/clocktest"
     "github.com/email-management-platform/backend/email-service/internal/models"
     "github.com/email-management-platform/backend/email-service/internal/repositories"
 )
 
+// waitIdlePollInterval is how often WaitIdle rechecks whether the service
+// has any in-flight requests left.
+const waitIdlePollInterval = time.Millisecond * 10
+
 // Constants for service configuration
 const (
     defaultPageSize          = 50
@@ -51,16 +56,39 @@ var (
         Name: "email_service_active_requests",
         Help: "Number of currently active requests",
     })
+
+    courierDispatchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+        Name: "email_service_courier_dispatch_duration_seconds",
+        Help: "Duration of a Courier.Dispatch call",
+        Buckets: prometheus.DefBuckets,
+    }, []string{"channel"})
+
+    courierDispatchErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "email_service_courier_dispatch_errors_total",
+        Help: "Total number of failed Courier.Dispatch calls",
+    }, []string{"channel"})
+
+    courierDispatchTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "email_service_courier_dispatch_total",
+        Help: "Total number of successful Courier.Dispatch calls",
+    }, []string{"channel"})
 )
 
 // EmailService handles email operations with enhanced reliability and monitoring
 type EmailService struct {
-    repo            *repositories.EmailRepository
-    rateLimiter     *rate.Limiter
-    circuitBreaker  *gobreaker.CircuitBreaker
-    cache           *cache.Cache
-    cacheMutex      *sync.RWMutex
-    metrics         *serviceMetrics
+    repo             *repositories.EmailRepository
+    rateLimiter      *rate.Limiter
+    circuitBreaker   *gobreaker.CircuitBreaker
+    cache            *tieredCache
+    bodyCache        *cache.Store
+    courier          Courier
+    deliveryQueue    *DeliveryQueue
+    templateRenderer *TemplateRenderer
+    sink             Sink
+    clock            clocktest.Clock
+    metrics          *serviceMetrics
+    replyDomain      string
+    replySecret      []byte
 }
 
 // serviceMetrics holds service-level metrics
@@ -69,14 +97,119 @@ type serviceMetrics struct {
     errors      *prometheus.CounterVec
     operations  *prometheus.CounterVec
     active      prometheus.Gauge
+    // activeCount mirrors active's value for in-process reads: Gauge has
+    // no way to read back what it was last set to, so WaitIdle polls this
+    // instead.
+    activeCount atomic.Int64
+}
+
+// serviceConfig collects the options applied by the Option functions
+// passed to NewEmailService.
+type serviceConfig struct {
+    cache            CacheOptions
+    bodyCache        *cache.Store
+    courier          Courier
+    deliveryQueue    *DeliveryQueue
+    templateRenderer *TemplateRenderer
+    sink             Sink
+    clock            clocktest.Clock
+    replyDomain      string
+    replySecret      []byte
+}
+
+// Option configures an EmailService built by NewEmailService.
+type Option func(*serviceConfig)
+
+// WithCache enables the service's disk-backed L2 cache tier. Omitting
+// this option keeps the original in-memory-only behavior.
+func WithCache(opts CacheOptions) Option {
+    return func(c *serviceConfig) {
+        c.cache = opts
+    }
+}
+
+// WithBodyCache gives EmailService access to an on-disk cache of raw
+// message/attachment bodies, consulted by GetRawBody/GetAttachmentBody.
+// Omitting this option disables raw body caching entirely; those calls
+// then always render (or read) the body fresh.
+func WithBodyCache(store *cache.Store) Option {
+    return func(c *serviceConfig) {
+        c.bodyCache = store
+    }
+}
+
+// WithCourier gives EmailService an outbound delivery path: once set,
+// processEmailWithRetry dispatches every successfully persisted email
+// through courier.
+func WithCourier(courier Courier) Option {
+    return func(c *serviceConfig) {
+        c.courier = courier
+    }
+}
+
+// WithDeliveryQueue makes processEmailWithRetry hand every dispatch off to
+// queue instead of calling the configured courier inline: delivery then
+// happens asynchronously, rate-limited and circuit-broken per destination
+// domain. queue must already have been started (queue.Start()) and built
+// from the same courier passed to WithCourier. Omitting this option
+// dispatches inline, as before.
+func WithDeliveryQueue(queue *DeliveryQueue) Option {
+    return func(c *serviceConfig) {
+        c.deliveryQueue = queue
+    }
+}
+
+// WithReplyAddressing makes processEmailWithRetry stamp a signed
+// reply+<token>@domain Reply-To header, generated via
+// models.GenerateReplyToken/Email.BuildReplyAddress, onto every email it
+// dispatches through the service's courier. Omitting this option leaves
+// Reply-To untouched.
+func WithReplyAddressing(domain string, secret []byte) Option {
+    return func(c *serviceConfig) {
+        c.replyDomain = domain
+        c.replySecret = secret
+    }
+}
+
+// WithTemplateRenderer stores renderer on the service. Neither the service
+// nor its couriers read it: SMTPCourier and SMSCourier send an email's
+// Content as-is.
+func WithTemplateRenderer(renderer *TemplateRenderer) Option {
+    return func(c *serviceConfig) {
+        c.templateRenderer = renderer
+    }
+}
+
+// WithSink observes every email successfully dispatched through the
+// service's courier. Tests use a ChannelSink to assert on delivered
+// payloads without a network call; production code has no need for one.
+func WithSink(sink Sink) Option {
+    return func(c *serviceConfig) {
+        c.sink = sink
+    }
+}
+
+// WithClock overrides the Clock used to schedule retry backoff delays.
+// Tests pass a clocktest.FakeClock so retry/backoff paths can be
+// exercised by advancing simulated time instead of sleeping through real
+// delays.
+func WithClock(clock clocktest.Clock) Option {
+    return func(c *serviceConfig) {
+        c.clock = clock
+    }
 }
 
-// NewEmailService creates a new instance of EmailService with enhanced features
-func NewEmailService(repo *repositories.EmailRepository) (*EmailService, error) {
+// NewEmailService creates a new instance of EmailService with enhanced features.
+func NewEmailService(repo *repositories.EmailRepository, opts ...Option) (*EmailService, error) {
     if repo == nil {
         return nil, errors.New("repository is required")
     }
 
+    cfg := &serviceConfig{}
+    for _, opt := range opts {
+        opt(cfg)
+    }
+
     // Initialize circuit breaker
     cb := gobreaker.NewCircuitBreaker(gobreaker.Settings{
         Name:        "email_service",
@@ -91,12 +224,34 @@ func NewEmailService(repo *repositories.EmailRepository) (*EmailService, error)
         },
     })
 
+    tc, err := newTieredCache(cfg.cache)
+    if err != nil {
+        return nil, errors.Wrap(err, "failed to initialize cache")
+    }
+
+    sink := cfg.sink
+    if sink == nil {
+        sink = noopSink{}
+    }
+
+    clock := cfg.clock
+    if clock == nil {
+        clock = clocktest.NewRealClock()
+    }
+
     service := &EmailService{
-        repo:           repo,
-        rateLimiter:    rate.NewLimiter(rate.Limit(maxConcurrentRequests), maxConcurrentRequests),
-        circuitBreaker: cb,
-        cache:          cache.New(cacheTTL, cacheTTL*2),
-        cacheMutex:     &sync.RWMutex{},
+        repo:             repo,
+        rateLimiter:      rate.NewLimiter(rate.Limit(maxConcurrentRequests), maxConcurrentRequests),
+        circuitBreaker:   cb,
+        cache:            tc,
+        bodyCache:        cfg.bodyCache,
+        courier:          cfg.courier,
+        deliveryQueue:    cfg.deliveryQueue,
+        templateRenderer: cfg.templateRenderer,
+        sink:             sink,
+        clock:            clock,
+        replyDomain:      cfg.replyDomain,
+        replySecret:      cfg.replySecret,
         metrics: &serviceMetrics{
             duration:    emailOperationDuration,
             errors:      emailOperationErrors,
@@ -108,13 +263,32 @@ func NewEmailService(repo *repositories.EmailRepository) (*EmailService, error)
     return service, nil
 }
 
+// Warmup primes the in-memory cache tier from disk, so a freshly started
+// process doesn't pay the slower L2 lookup cost for data it already has
+// cached from a prior run.
+func (s *EmailService) Warmup(ctx context.Context) error {
+    return s.cache.warmup(ctx)
+}
+
+// Close releases resources held by the service, including the L2 cache's
+// disk database handle and, if configured, the delivery queue's worker
+// pool.
+func (s *EmailService) Close() error {
+    if s.deliveryQueue != nil {
+        s.deliveryQueue.Stop()
+    }
+    return s.cache.close()
+}
+
 // ProcessEmail handles email processing with retries and monitoring
 func (s *EmailService) ProcessEmail(ctx context.Context, email *models.Email) error {
     timer := prometheus.NewTimer(s.metrics.duration.WithLabelValues("process_email"))
     defer timer.ObserveDuration()
 
     s.metrics.active.Inc()
+    s.metrics.activeCount.Add(1)
     defer s.metrics.active.Dec()
+    defer s.metrics.activeCount.Add(-1)
 
     // Apply rate limiting
     if err := s.rateLimiter.Wait(ctx); err != nil {
@@ -141,12 +315,14 @@ func (s *EmailService) ProcessEmail(ctx context.Context, email *models.Email) er
 func (s *EmailService) processEmailWithRetry(ctx context.Context, email *models.Email) error {
     var lastErr error
 
+    s.stampReplyAddress(email)
+
     for attempt := 0; attempt < maxRetries; attempt++ {
         if attempt > 0 {
             select {
             case <-ctx.Done():
                 return ctx.Err()
-            case <-time.After(retryDelay * time.Duration(attempt)):
+            case <-s.clock.After(retryDelay * time.Duration(attempt)):
             }
         }
 
@@ -156,6 +332,14 @@ func (s *EmailService) processEmailWithRetry(ctx context.Context, email *models.
             continue
         }
 
+        if s.courier != nil {
+            if err := s.dispatch(ctx, email); err != nil {
+                lastErr = err
+                s.metrics.errors.WithLabelValues("process_email_retry", fmt.Sprintf("attempt_%d", attempt+1)).Inc()
+                continue
+            }
+        }
+
         // Cache successful result
         s.cacheEmail(email)
         return nil
@@ -164,13 +348,77 @@ func (s *EmailService) processEmailWithRetry(ctx context.Context, email *models.
     return errors.Wrap(lastErr, "max retries exceeded")
 }
 
+// stampReplyAddress sets email's Reply-To header to a signed
+// reply+<token>@replyDomain address, verifiable by internal/incoming's
+// Fetcher, so a reply to this email can be resolved back to its thread
+// without relying on In-Reply-To/References alone. A no-op unless
+// WithReplyAddressing was passed to NewEmailService.
+func (s *EmailService) stampReplyAddress(email *models.Email) {
+    if len(s.replySecret) == 0 || s.replyDomain == "" || email.MessageID == "" {
+        return
+    }
+    if email.Headers == nil {
+        email.Headers = make(map[string]string)
+    }
+    token := models.GenerateReplyToken(email.MessageID, email.AccountID, s.replySecret)
+    email.Headers["Reply-To"] = email.BuildReplyAddress(s.replyDomain, token)
+}
+
+// dispatch hands email to the configured courier, recording per-channel
+// duration and outcome metrics. When a DeliveryQueue is configured
+// (WithDeliveryQueue), email is enqueued for async delivery instead; the
+// queue records the same metrics once that delivery completes, and records
+// the sent message to its own DeliveryQueueOptions.Sink, not to the
+// service's Sink.
+func (s *EmailService) dispatch(ctx context.Context, email *models.Email) error {
+    if s.deliveryQueue != nil {
+        if err := s.deliveryQueue.Enqueue(email); err != nil {
+            return errors.Wrap(err, "delivery queue enqueue failed")
+        }
+        return nil
+    }
+
+    channel := s.courier.Channel()
+    timer := prometheus.NewTimer(courierDispatchDuration.WithLabelValues(channel))
+    defer timer.ObserveDuration()
+
+    if err := s.courier.Dispatch(ctx, email); err != nil {
+        courierDispatchErrors.WithLabelValues(channel).Inc()
+        return errors.Wrap(err, "courier dispatch failed")
+    }
+
+    courierDispatchTotal.WithLabelValues(channel).Inc()
+    s.sink.Record(&SentMessage{MessageID: email.MessageID, Channel: channel, Email: email})
+    return nil
+}
+
+// WaitIdle blocks until the service has no in-flight ProcessEmail or
+// GetEmailByID calls, or ctx is cancelled, whichever comes first. Tests
+// use it to synchronize on the service draining before asserting on a
+// sink's delivered messages.
+func (s *EmailService) WaitIdle(ctx context.Context) error {
+    for {
+        if s.metrics.activeCount.Load() == 0 {
+            return nil
+        }
+
+        select {
+        case <-ctx.Done():
+            return ctx.Err()
+        case <-s.clock.After(waitIdlePollInterval):
+        }
+    }
+}
+
 // GetEmailByID retrieves an email by ID with caching
 func (s *EmailService) GetEmailByID(ctx context.Context, messageID, accountID string) (*models.Email, error) {
     timer := prometheus.NewTimer(s.metrics.duration.WithLabelValues("get_email"))
     defer timer.ObserveDuration()
 
     s.metrics.active.Inc()
+    s.metrics.activeCount.Add(1)
     defer s.metrics.active.Dec()
+    defer s.metrics.activeCount.Add(-1)
 
     // Check cache first
     if email := s.getCachedEmail(messageID); email != nil {
@@ -198,20 +446,15 @@ func (s *EmailService) GetEmailByID(ctx context.Context, messageID, accountID st
     return email, nil
 }
 
-// cacheEmail stores email in cache
+// cacheEmail stores email in the L1/L2 cache
 func (s *EmailService) cacheEmail(email *models.Email) {
-    s.cacheMutex.Lock()
-    defer s.cacheMutex.Unlock()
-    s.cache.Set(email.MessageID, email, cache.DefaultExpiration)
+    s.cache.set(email)
 }
 
-// getCachedEmail retrieves email from cache
+// getCachedEmail retrieves email from the L1/L2 cache
 func (s *EmailService) getCachedEmail(messageID string) *models.Email {
-    s.cacheMutex.RLock()
-    defer s.cacheMutex.RUnlock()
-    
-    if cached, found := s.cache.Get(messageID); found {
-        return cached.(*models.Email)
+    if email, found := s.cache.get(messageID); found {
+        return email
     }
     return nil
 }
@@ -222,7 +465,7 @@ func (s *EmailService) GetHealth() map[string]interface{} {
         "status":           "healthy",
         "circuit_breaker": s.circuitBreaker.State().String(),
         "active_requests": s.metrics.active.Value(),
-        "cache_items":     s.cache.ItemCount(),
+        "cache_items":     s.cache.l1.ItemCount(),
     }
 }
 
@@ -0,0 +1,52 @@
+package services
+
+import (
+    "github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// SentMessage records one email a Courier successfully dispatched,
+// handed to a Sink for observation.
+type SentMessage struct {
+    MessageID string
+    Channel   string
+    Email     *models.Email
+}
+
+// Sink observes every email EmailService successfully dispatches through
+// its courier. Production code has no need for one; it exists so tests
+// can assert on delivered payloads via ChannelSink instead of standing up
+// a real transport.
+type Sink interface {
+    Record(msg *SentMessage)
+}
+
+// noopSink is the Sink used when WithSink isn't passed to NewEmailService.
+type noopSink struct{}
+
+func (noopSink) Record(*SentMessage) {}
+
+// ChannelSink is an in-memory Sink that buffers dispatched messages on a
+// channel, mirroring the emailSink-channel pattern used elsewhere for
+// asserting on delivered payloads without a network call.
+type ChannelSink struct {
+    messages chan *SentMessage
+}
+
+// NewChannelSink creates a ChannelSink buffering up to capacity messages
+// before Record starts dropping them.
+func NewChannelSink(capacity int) *ChannelSink {
+    return &ChannelSink{messages: make(chan *SentMessage, capacity)}
+}
+
+// Record buffers msg, dropping it if the sink's buffer is full.
+func (s *ChannelSink) Record(msg *SentMessage) {
+    select {
+    case s.messages <- msg:
+    default:
+    }
+}
+
+// Messages returns the channel tests can drain dispatched messages from.
+func (s *ChannelSink) Messages() <-chan *SentMessage {
+    return s.messages
+}
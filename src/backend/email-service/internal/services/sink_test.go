@@ -0,0 +1,45 @@
+package services
+
+import (
+    "testing"
+
+    "github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// TestChannelSinkRecordsMessages verifies Record makes dispatched messages
+// available on Messages without a network call.
+func TestChannelSinkRecordsMessages(t *testing.T) {
+    sink := NewChannelSink(2)
+    email := &models.Email{MessageID: "msg-1"}
+
+    sink.Record(&SentMessage{MessageID: email.MessageID, Channel: ChannelSMTP, Email: email})
+
+    select {
+    case got := <-sink.Messages():
+        if got.MessageID != "msg-1" || got.Channel != ChannelSMTP {
+            t.Fatalf("unexpected message recorded: %+v", got)
+        }
+    default:
+        t.Fatal("expected a message to be available on the sink")
+    }
+}
+
+// TestChannelSinkDropsWhenFull verifies Record doesn't block once the
+// sink's buffer is full; the offending message is simply dropped.
+func TestChannelSinkDropsWhenFull(t *testing.T) {
+    sink := NewChannelSink(1)
+
+    sink.Record(&SentMessage{MessageID: "msg-1"})
+    sink.Record(&SentMessage{MessageID: "msg-2"})
+
+    got := <-sink.Messages()
+    if got.MessageID != "msg-1" {
+        t.Fatalf("expected the first recorded message to survive, got %q", got.MessageID)
+    }
+
+    select {
+    case extra := <-sink.Messages():
+        t.Fatalf("expected no further messages, got %+v", extra)
+    default:
+    }
+}
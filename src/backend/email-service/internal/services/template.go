@@ -0,0 +1,57 @@
+package services
+
+import (
+    "bytes"
+    "fmt"
+    "html/template"
+    "path/filepath"
+    texttemplate "text/template"
+)
+
+// TemplateRenderer renders per-channel email bodies from Go templates
+// loaded from a root directory (e.g. "smtp/notification.html",
+// "sms/notification.txt" under root). SMTPCourier and SMSCourier do not
+// call it; they send an email's Content as-is.
+type TemplateRenderer struct {
+    root string
+}
+
+// NewTemplateRenderer creates a TemplateRenderer that resolves template
+// names relative to root.
+func NewTemplateRenderer(root string) *TemplateRenderer {
+    return &TemplateRenderer{root: root}
+}
+
+// RenderHTML renders the named template (relative to root) with
+// html/template, escaping data for safe inclusion in an HTML body.
+func (r *TemplateRenderer) RenderHTML(name string, data interface{}) (string, error) {
+    path := filepath.Join(r.root, name)
+
+    tmpl, err := template.ParseFiles(path)
+    if err != nil {
+        return "", fmt.Errorf("template renderer: failed to parse %q: %w", name, err)
+    }
+
+    var buf bytes.Buffer
+    if err := tmpl.Execute(&buf, data); err != nil {
+        return "", fmt.Errorf("template renderer: failed to render %q: %w", name, err)
+    }
+    return buf.String(), nil
+}
+
+// RenderText renders the named template (relative to root) with
+// text/template, for channels like SMS where HTML escaping is undesired.
+func (r *TemplateRenderer) RenderText(name string, data interface{}) (string, error) {
+    path := filepath.Join(r.root, name)
+
+    tmpl, err := texttemplate.ParseFiles(path)
+    if err != nil {
+        return "", fmt.Errorf("template renderer: failed to parse %q: %w", name, err)
+    }
+
+    var buf bytes.Buffer
+    if err := tmpl.Execute(&buf, data); err != nil {
+        return "", fmt.Errorf("template renderer: failed to render %q: %w", name, err)
+    }
+    return buf.String(), nil
+}
@@ -0,0 +1,45 @@
+package smtp
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// NoopSender discards every email handed to it, for tests and local
+// development where no outbound mail should actually be sent.
+type NoopSender struct{}
+
+// NoopSender implements EmailSender.
+var _ EmailSender = NoopSender{}
+
+// Send does nothing and always succeeds.
+func (NoopSender) Send(ctx context.Context, email *models.Email) error {
+	return nil
+}
+
+// LogSender logs every email handed to it instead of sending it, for
+// local development where seeing outbound mail in the console is more
+// useful than actually delivering it.
+type LogSender struct {
+	// Logger is used to log each email. Defaults to slog.Default() if nil.
+	Logger *slog.Logger
+}
+
+// LogSender implements EmailSender.
+var _ EmailSender = (*LogSender)(nil)
+
+// Send logs email's recipients and subject and always succeeds.
+func (l *LogSender) Send(ctx context.Context, email *models.Email) error {
+	logger := l.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.Info("smtp sender: email not sent (log sender)",
+		"to", email.ToAddresses,
+		"subject", email.Subject,
+		"message_id", email.MessageID,
+	)
+	return nil
+}
@@ -0,0 +1,229 @@
+// Package smtp delivers rendered models.Email messages over SMTP,
+// independent of internal/services.Courier: Courier is EmailService's
+// retry-aware outbound dispatch path for processed mail, while EmailSender
+// targets templated transactional mail (confirmation links, password
+// resets) that callers build directly with internal/templates and send
+// without going through the processing pipeline.
+package smtp
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"sync"
+
+	gosmtp "net/smtp"
+
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// EmailSender delivers a fully rendered models.Email to its recipients.
+type EmailSender interface {
+	Send(ctx context.Context, email *models.Email) error
+}
+
+// SenderConfig configures SMTPSender.
+type SenderConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// From overrides the envelope and header "From" address; when empty,
+	// email.FromAddress is used.
+	From string
+	// UseSTARTTLS upgrades the connection with STARTTLS before AUTH, for
+	// servers that only advertise plaintext on their listening port.
+	UseSTARTTLS bool
+	// InsecureSkipVerify disables TLS certificate verification. Only
+	// intended for local/test SMTP servers using a self-signed certificate.
+	InsecureSkipVerify bool
+}
+
+// SMTPSender delivers mail through a single SMTP server, reusing one
+// connection across calls to Send rather than dialing fresh for every
+// message.
+type SMTPSender struct {
+	cfg SenderConfig
+
+	mu     sync.Mutex
+	client *gosmtp.Client
+}
+
+// SMTPSender implements EmailSender.
+var _ EmailSender = (*SMTPSender)(nil)
+
+// NewSMTPSender creates an SMTPSender that relays through cfg.Host:cfg.Port.
+// The connection is dialed lazily, on the first call to Send.
+func NewSMTPSender(cfg SenderConfig) *SMTPSender {
+	return &SMTPSender{cfg: cfg}
+}
+
+// Send delivers email over the sender's connection, reusing it if the
+// previous call left it healthy and redialing otherwise.
+func (s *SMTPSender) Send(ctx context.Context, email *models.Email) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client, err := s.connection()
+	if err != nil {
+		return fmt.Errorf("smtp sender: failed to connect: %w", err)
+	}
+
+	from := s.cfg.From
+	if from == "" {
+		from = email.FromAddress
+	}
+
+	recipients := make([]string, 0, len(email.ToAddresses)+len(email.CCAddresses)+len(email.BCCAddresses))
+	recipients = append(recipients, email.ToAddresses...)
+	recipients = append(recipients, email.CCAddresses...)
+	recipients = append(recipients, email.BCCAddresses...)
+
+	if err := client.Mail(from); err != nil {
+		s.reset()
+		return fmt.Errorf("smtp sender: MAIL FROM failed: %w", err)
+	}
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			s.reset()
+			return fmt.Errorf("smtp sender: RCPT TO %s failed: %w", recipient, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		s.reset()
+		return fmt.Errorf("smtp sender: DATA failed: %w", err)
+	}
+	if _, err := w.Write(buildMessage(email, from)); err != nil {
+		w.Close()
+		s.reset()
+		return fmt.Errorf("smtp sender: failed to write message body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		s.reset()
+		return fmt.Errorf("smtp sender: failed to finish message: %w", err)
+	}
+
+	return nil
+}
+
+// connection returns the sender's cached SMTP client, (re)dialing if this
+// is the first call or the cached connection has gone bad. Callers must
+// hold s.mu.
+func (s *SMTPSender) connection() (*gosmtp.Client, error) {
+	if s.client != nil {
+		if err := s.client.Noop(); err == nil {
+			return s.client, nil
+		}
+		s.client.Close()
+		s.client = nil
+	}
+
+	addr := fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)
+	client, err := gosmtp.Dial(addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s: %w", addr, err)
+	}
+
+	if s.cfg.UseSTARTTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			tlsConfig := &tls.Config{ServerName: s.cfg.Host, InsecureSkipVerify: s.cfg.InsecureSkipVerify}
+			if err := client.StartTLS(tlsConfig); err != nil {
+				client.Close()
+				return nil, fmt.Errorf("starttls failed: %w", err)
+			}
+		}
+	}
+
+	if s.cfg.Username != "" {
+		auth := gosmtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+		if err := client.Auth(auth); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("auth failed: %w", err)
+		}
+	}
+
+	s.client = client
+	return s.client, nil
+}
+
+// reset drops the cached connection after a failed command, so the next
+// Send dials fresh instead of reusing a connection left in a bad state.
+// Callers must hold s.mu.
+func (s *SMTPSender) reset() {
+	if s.client != nil {
+		s.client.Close()
+		s.client = nil
+	}
+}
+
+// Close closes the sender's cached connection, if one is open.
+func (s *SMTPSender) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.client == nil {
+		return nil
+	}
+	err := s.client.Quit()
+	s.client = nil
+	return err
+}
+
+// messageBoundary separates the plain-text and HTML parts of a
+// multipart/alternative message built by buildMessage.
+const messageBoundary = "smtp-sender-boundary"
+
+// headerInjectionReplacer strips CR and LF from a value before it's
+// written into a raw RFC 5322 header line, so a Subject, address, or
+// Headers entry containing an embedded newline can't inject extra headers
+// or terminate the header block early.
+var headerInjectionReplacer = strings.NewReplacer("\r", "", "\n", "")
+
+// sanitizeHeaderValue returns v with any embedded CR/LF removed, safe to
+// write as the value of a single raw header line.
+func sanitizeHeaderValue(v string) string {
+	return headerInjectionReplacer.Replace(v)
+}
+
+// buildMessage renders email as a MIME message addressed from from. When
+// email.PlainTextAlternative is set, the message is sent as
+// multipart/alternative with both bodies; otherwise Content is sent alone
+// as text/html.
+func buildMessage(email *models.Email, from string) []byte {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "From: %s\r\n", sanitizeHeaderValue(from))
+	fmt.Fprintf(&buf, "To: %s\r\n", sanitizeHeaderValue(strings.Join(email.ToAddresses, ", ")))
+	if len(email.CCAddresses) > 0 {
+		fmt.Fprintf(&buf, "Cc: %s\r\n", sanitizeHeaderValue(strings.Join(email.CCAddresses, ", ")))
+	}
+	if email.MessageID != "" {
+		fmt.Fprintf(&buf, "Message-ID: %s\r\n", sanitizeHeaderValue(email.MessageID))
+	}
+	fmt.Fprintf(&buf, "Subject: %s\r\n", sanitizeHeaderValue(email.Subject))
+	for k, v := range email.Headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", sanitizeHeaderValue(k), sanitizeHeaderValue(v))
+	}
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if email.PlainTextAlternative == "" {
+		buf.WriteString("Content-Type: text/html; charset=utf-8\r\n\r\n")
+		buf.WriteString(email.Content)
+		return []byte(buf.String())
+	}
+
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", messageBoundary)
+	fmt.Fprintf(&buf, "--%s\r\n", messageBoundary)
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	buf.WriteString(email.PlainTextAlternative)
+	buf.WriteString("\r\n")
+	fmt.Fprintf(&buf, "--%s\r\n", messageBoundary)
+	buf.WriteString("Content-Type: text/html; charset=utf-8\r\n\r\n")
+	buf.WriteString(email.Content)
+	buf.WriteString("\r\n")
+	fmt.Fprintf(&buf, "--%s--\r\n", messageBoundary)
+
+	return []byte(buf.String())
+}
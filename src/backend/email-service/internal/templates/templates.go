@@ -0,0 +1,153 @@
+// Package templates renders predefined transactional messages (account
+// confirmation, password reset, new-device login notices) into a
+// models.Email ready to hand to an smtp.EmailSender.
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// Key identifies a predefined transactional message template.
+type Key string
+
+// Predefined transactional template keys.
+const (
+	ConfirmEmail   Key = "confirm_email"
+	PasswordReset  Key = "password_reset"
+	NewDeviceLogin Key = "new_device_login"
+)
+
+// defaultLocale is used when Render is asked for a locale with no
+// registered templates.
+const defaultLocale = "en"
+
+// baseLayout wraps every rendered template's body in a shared HTML shell.
+const baseLayout = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"></head>
+<body>
+{{.Content}}
+</body>
+</html>
+`
+
+// subjects holds each template's subject line, keyed by locale then Key.
+var subjects = map[string]map[Key]string{
+	defaultLocale: {
+		ConfirmEmail:   "Confirm your email address",
+		PasswordReset:  "Reset your password",
+		NewDeviceLogin: "New device signed in to your account",
+	},
+}
+
+// bodies holds each template's body source, keyed by locale then Key.
+// Bodies are parsed with html/template, so interpolated data is escaped
+// automatically.
+var bodies = map[string]map[Key]string{
+	defaultLocale: {
+		ConfirmEmail: `<p>Hi {{.RecipientName}},</p>
+<p>Please confirm your email address by visiting the link below. This link expires in {{.ExpiresIn}}.</p>
+<p><a href="{{.ActionURL}}">Confirm your email</a></p>`,
+
+		PasswordReset: `<p>Hi {{.RecipientName}},</p>
+<p>We received a request to reset your password. This link expires in {{.ExpiresIn}}.</p>
+<p><a href="{{.ActionURL}}">Reset your password</a></p>
+<p>If you didn't request this, you can safely ignore this email.</p>`,
+
+		NewDeviceLogin: `<p>Hi {{.RecipientName}},</p>
+<p>Your account was just signed in to from a new device: {{.DeviceDescription}}.</p>
+<p>If this was you, no action is needed. If it wasn't, please reset your password.</p>
+<p><a href="{{.ActionURL}}">Review account activity</a></p>`,
+	},
+}
+
+// tagPattern matches a single HTML tag, for generating a plain-text
+// alternative from rendered HTML.
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// layoutData is the data baseLayout is executed with.
+type layoutData struct {
+	Content template.HTML
+}
+
+// Renderer renders predefined transactional templates into a models.Email.
+// It parses every registered locale/key combination once, at construction
+// time, rather than on every Render call.
+type Renderer struct {
+	layout *template.Template
+	bodies map[string]map[Key]*template.Template
+}
+
+// NewRenderer parses every registered template and returns a ready-to-use
+// Renderer.
+func NewRenderer() (*Renderer, error) {
+	layout, err := template.New("layout").Parse(baseLayout)
+	if err != nil {
+		return nil, fmt.Errorf("templates: failed to parse base layout: %w", err)
+	}
+
+	parsed := make(map[string]map[Key]*template.Template, len(bodies))
+	for locale, keyed := range bodies {
+		parsed[locale] = make(map[Key]*template.Template, len(keyed))
+		for key, source := range keyed {
+			tmpl, err := template.New(string(key)).Parse(source)
+			if err != nil {
+				return nil, fmt.Errorf("templates: failed to parse %s/%s: %w", locale, key, err)
+			}
+			parsed[locale][key] = tmpl
+		}
+	}
+
+	return &Renderer{layout: layout, bodies: parsed}, nil
+}
+
+// Render builds a transactional models.Email for key in locale, populated
+// with data. It falls back to defaultLocale if locale has no templates
+// registered. The returned Email's Content holds the rendered HTML body
+// (wrapped in the shared base layout) and PlainTextAlternative holds the
+// same body with tags stripped, for MIME clients that prefer text/plain.
+// Render does not populate recipient addresses or a MessageID; callers
+// set those before handing the Email to an smtp.EmailSender.
+func (r *Renderer) Render(key Key, locale string, data interface{}) (*models.Email, error) {
+	keyed, ok := r.bodies[locale]
+	if !ok {
+		keyed, ok = r.bodies[defaultLocale]
+		locale = defaultLocale
+	}
+	if !ok {
+		return nil, fmt.Errorf("templates: no templates registered for locale %q", locale)
+	}
+
+	bodyTmpl, ok := keyed[key]
+	if !ok {
+		return nil, fmt.Errorf("templates: unknown template key %q for locale %q", key, locale)
+	}
+
+	var content bytes.Buffer
+	if err := bodyTmpl.Execute(&content, data); err != nil {
+		return nil, fmt.Errorf("templates: failed to render %q: %w", key, err)
+	}
+
+	var html bytes.Buffer
+	if err := r.layout.Execute(&html, layoutData{Content: template.HTML(content.String())}); err != nil {
+		return nil, fmt.Errorf("templates: failed to render layout for %q: %w", key, err)
+	}
+
+	return &models.Email{
+		Subject:              subjects[locale][key],
+		Content:              html.String(),
+		PlainTextAlternative: stripTags(content.String()),
+	}, nil
+}
+
+// stripTags returns html with every tag removed, for generating a
+// plain-text alternative from an HTML template's rendered output.
+func stripTags(html string) string {
+	return strings.TrimSpace(tagPattern.ReplaceAllString(html, ""))
+}
@@ -0,0 +1,402 @@
+// Code generated by protoc-gen-go from proto/email/v1/email_service.proto.
+// Regenerate with:
+//
+//	protoc --go_out=. --go_opt=module=github.com/email-management-platform/backend/email-service \
+//	  --go-grpc_out=. --go-grpc_opt=module=github.com/email-management-platform/backend/email-service \
+//	  proto/email/v1/email_service.proto
+//
+// DO NOT EDIT by hand except to keep pace with changes to the .proto file.
+package emailgrpc
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// EmailMessage mirrors email.v1.EmailMessage.
+type EmailMessage struct {
+	MessageId      string               `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ThreadId       string               `protobuf:"bytes,2,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	ConversationId string               `protobuf:"bytes,3,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	AccountId      string               `protobuf:"bytes,4,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Subject        string               `protobuf:"bytes,5,opt,name=subject,proto3" json:"subject,omitempty"`
+	Content        string               `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
+	FromAddress    string               `protobuf:"bytes,7,opt,name=from_address,json=fromAddress,proto3" json:"from_address,omitempty"`
+	ToAddresses    []string             `protobuf:"bytes,8,rep,name=to_addresses,json=toAddresses,proto3" json:"to_addresses,omitempty"`
+	CcAddresses    []string             `protobuf:"bytes,9,rep,name=cc_addresses,json=ccAddresses,proto3" json:"cc_addresses,omitempty"`
+	BccAddresses   []string             `protobuf:"bytes,10,rep,name=bcc_addresses,json=bccAddresses,proto3" json:"bcc_addresses,omitempty"`
+	Labels         []string             `protobuf:"bytes,11,rep,name=labels,proto3" json:"labels,omitempty"`
+	FolderPath     string               `protobuf:"bytes,12,opt,name=folder_path,json=folderPath,proto3" json:"folder_path,omitempty"`
+	SentAt         *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+	ReceivedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+}
+
+func (m *EmailMessage) Reset()         { *m = EmailMessage{} }
+func (m *EmailMessage) String() string { return fmt.Sprintf("%+v", *m) }
+func (*EmailMessage) ProtoMessage()    {}
+
+// GetEmailRequest mirrors email.v1.GetEmailRequest.
+type GetEmailRequest struct {
+	MessageId string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	AccountId string `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+}
+
+func (m *GetEmailRequest) Reset()         { *m = GetEmailRequest{} }
+func (m *GetEmailRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*GetEmailRequest) ProtoMessage()    {}
+
+func (m *GetEmailRequest) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
+}
+
+func (m *GetEmailRequest) GetAccountId() string {
+	if m != nil {
+		return m.AccountId
+	}
+	return ""
+}
+
+// ListEmailsRequest mirrors email.v1.ListEmailsRequest.
+type ListEmailsRequest struct {
+	AccountId  string `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	PageSize   int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken  string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	FolderPath string `protobuf:"bytes,4,opt,name=folder_path,json=folderPath,proto3" json:"folder_path,omitempty"`
+}
+
+func (m *ListEmailsRequest) Reset()         { *m = ListEmailsRequest{} }
+func (m *ListEmailsRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ListEmailsRequest) ProtoMessage()    {}
+
+func (m *ListEmailsRequest) GetAccountId() string {
+	if m != nil {
+		return m.AccountId
+	}
+	return ""
+}
+
+func (m *ListEmailsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListEmailsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListEmailsRequest) GetFolderPath() string {
+	if m != nil {
+		return m.FolderPath
+	}
+	return ""
+}
+
+// ListEmailsResponse mirrors email.v1.ListEmailsResponse.
+type ListEmailsResponse struct {
+	Emails        []*EmailMessage `protobuf:"bytes,1,rep,name=emails,proto3" json:"emails,omitempty"`
+	NextPageToken string          `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (m *ListEmailsResponse) Reset()         { *m = ListEmailsResponse{} }
+func (m *ListEmailsResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ListEmailsResponse) ProtoMessage()    {}
+
+func (m *ListEmailsResponse) GetEmails() []*EmailMessage {
+	if m != nil {
+		return m.Emails
+	}
+	return nil
+}
+
+func (m *ListEmailsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// SendEmailRequest mirrors email.v1.SendEmailRequest.
+type SendEmailRequest struct {
+	Subject      string   `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	TextBody     string   `protobuf:"bytes,2,opt,name=text_body,json=textBody,proto3" json:"text_body,omitempty"`
+	HtmlBody     string   `protobuf:"bytes,3,opt,name=html_body,json=htmlBody,proto3" json:"html_body,omitempty"`
+	FromAddress  string   `protobuf:"bytes,4,opt,name=from_address,json=fromAddress,proto3" json:"from_address,omitempty"`
+	ToAddresses  []string `protobuf:"bytes,5,rep,name=to_addresses,json=toAddresses,proto3" json:"to_addresses,omitempty"`
+	CcAddresses  []string `protobuf:"bytes,6,rep,name=cc_addresses,json=ccAddresses,proto3" json:"cc_addresses,omitempty"`
+	BccAddresses []string `protobuf:"bytes,7,rep,name=bcc_addresses,json=bccAddresses,proto3" json:"bcc_addresses,omitempty"`
+}
+
+func (m *SendEmailRequest) Reset()         { *m = SendEmailRequest{} }
+func (m *SendEmailRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*SendEmailRequest) ProtoMessage()    {}
+
+func (m *SendEmailRequest) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *SendEmailRequest) GetTextBody() string {
+	if m != nil {
+		return m.TextBody
+	}
+	return ""
+}
+
+func (m *SendEmailRequest) GetHtmlBody() string {
+	if m != nil {
+		return m.HtmlBody
+	}
+	return ""
+}
+
+func (m *SendEmailRequest) GetFromAddress() string {
+	if m != nil {
+		return m.FromAddress
+	}
+	return ""
+}
+
+func (m *SendEmailRequest) GetToAddresses() []string {
+	if m != nil {
+		return m.ToAddresses
+	}
+	return nil
+}
+
+func (m *SendEmailRequest) GetCcAddresses() []string {
+	if m != nil {
+		return m.CcAddresses
+	}
+	return nil
+}
+
+func (m *SendEmailRequest) GetBccAddresses() []string {
+	if m != nil {
+		return m.BccAddresses
+	}
+	return nil
+}
+
+// SendEmailResponse mirrors email.v1.SendEmailResponse.
+type SendEmailResponse struct {
+	MessageId string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+}
+
+func (m *SendEmailResponse) Reset()         { *m = SendEmailResponse{} }
+func (m *SendEmailResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*SendEmailResponse) ProtoMessage()    {}
+
+func (m *SendEmailResponse) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
+}
+
+// DeleteEmailRequest mirrors email.v1.DeleteEmailRequest.
+type DeleteEmailRequest struct {
+	MessageId string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	AccountId string `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+}
+
+func (m *DeleteEmailRequest) Reset()         { *m = DeleteEmailRequest{} }
+func (m *DeleteEmailRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*DeleteEmailRequest) ProtoMessage()    {}
+
+func (m *DeleteEmailRequest) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
+}
+
+func (m *DeleteEmailRequest) GetAccountId() string {
+	if m != nil {
+		return m.AccountId
+	}
+	return ""
+}
+
+// DeleteEmailResponse mirrors email.v1.DeleteEmailResponse.
+type DeleteEmailResponse struct{}
+
+func (m *DeleteEmailResponse) Reset()         { *m = DeleteEmailResponse{} }
+func (m *DeleteEmailResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*DeleteEmailResponse) ProtoMessage()    {}
+
+// UpdateLabelsRequest mirrors email.v1.UpdateLabelsRequest.
+type UpdateLabelsRequest struct {
+	MessageId    string   `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	AccountId    string   `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AddLabels    []string `protobuf:"bytes,3,rep,name=add_labels,json=addLabels,proto3" json:"add_labels,omitempty"`
+	RemoveLabels []string `protobuf:"bytes,4,rep,name=remove_labels,json=removeLabels,proto3" json:"remove_labels,omitempty"`
+}
+
+func (m *UpdateLabelsRequest) Reset()         { *m = UpdateLabelsRequest{} }
+func (m *UpdateLabelsRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*UpdateLabelsRequest) ProtoMessage()    {}
+
+func (m *UpdateLabelsRequest) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
+}
+
+func (m *UpdateLabelsRequest) GetAccountId() string {
+	if m != nil {
+		return m.AccountId
+	}
+	return ""
+}
+
+func (m *UpdateLabelsRequest) GetAddLabels() []string {
+	if m != nil {
+		return m.AddLabels
+	}
+	return nil
+}
+
+func (m *UpdateLabelsRequest) GetRemoveLabels() []string {
+	if m != nil {
+		return m.RemoveLabels
+	}
+	return nil
+}
+
+// UpdateLabelsResponse mirrors email.v1.UpdateLabelsResponse.
+type UpdateLabelsResponse struct{}
+
+func (m *UpdateLabelsResponse) Reset()         { *m = UpdateLabelsResponse{} }
+func (m *UpdateLabelsResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*UpdateLabelsResponse) ProtoMessage()    {}
+
+// MoveToFolderRequest mirrors email.v1.MoveToFolderRequest.
+type MoveToFolderRequest struct {
+	MessageId  string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	AccountId  string `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	FolderPath string `protobuf:"bytes,3,opt,name=folder_path,json=folderPath,proto3" json:"folder_path,omitempty"`
+}
+
+func (m *MoveToFolderRequest) Reset()         { *m = MoveToFolderRequest{} }
+func (m *MoveToFolderRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*MoveToFolderRequest) ProtoMessage()    {}
+
+func (m *MoveToFolderRequest) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
+}
+
+func (m *MoveToFolderRequest) GetAccountId() string {
+	if m != nil {
+		return m.AccountId
+	}
+	return ""
+}
+
+func (m *MoveToFolderRequest) GetFolderPath() string {
+	if m != nil {
+		return m.FolderPath
+	}
+	return ""
+}
+
+// MoveToFolderResponse mirrors email.v1.MoveToFolderResponse.
+type MoveToFolderResponse struct{}
+
+func (m *MoveToFolderResponse) Reset()         { *m = MoveToFolderResponse{} }
+func (m *MoveToFolderResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*MoveToFolderResponse) ProtoMessage()    {}
+
+// GetThreadRequest mirrors email.v1.GetThreadRequest.
+type GetThreadRequest struct {
+	ThreadId  string `protobuf:"bytes,1,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	AccountId string `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+}
+
+func (m *GetThreadRequest) Reset()         { *m = GetThreadRequest{} }
+func (m *GetThreadRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*GetThreadRequest) ProtoMessage()    {}
+
+func (m *GetThreadRequest) GetThreadId() string {
+	if m != nil {
+		return m.ThreadId
+	}
+	return ""
+}
+
+func (m *GetThreadRequest) GetAccountId() string {
+	if m != nil {
+		return m.AccountId
+	}
+	return ""
+}
+
+// ThreadResponse mirrors email.v1.ThreadResponse.
+type ThreadResponse struct {
+	Emails []*EmailMessage `protobuf:"bytes,1,rep,name=emails,proto3" json:"emails,omitempty"`
+}
+
+func (m *ThreadResponse) Reset()         { *m = ThreadResponse{} }
+func (m *ThreadResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ThreadResponse) ProtoMessage()    {}
+
+func (m *ThreadResponse) GetEmails() []*EmailMessage {
+	if m != nil {
+		return m.Emails
+	}
+	return nil
+}
+
+// WatchEmailsRequest mirrors email.v1.WatchEmailsRequest.
+type WatchEmailsRequest struct {
+	AccountId string `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+}
+
+func (m *WatchEmailsRequest) Reset()         { *m = WatchEmailsRequest{} }
+func (m *WatchEmailsRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*WatchEmailsRequest) ProtoMessage()    {}
+
+func (m *WatchEmailsRequest) GetAccountId() string {
+	if m != nil {
+		return m.AccountId
+	}
+	return ""
+}
+
+// EmailEvent mirrors email.v1.EmailEvent.
+type EmailEvent struct {
+	Email     *EmailMessage `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Keepalive bool          `protobuf:"varint,2,opt,name=keepalive,proto3" json:"keepalive,omitempty"`
+}
+
+func (m *EmailEvent) Reset()         { *m = EmailEvent{} }
+func (m *EmailEvent) String() string { return fmt.Sprintf("%+v", *m) }
+func (*EmailEvent) ProtoMessage()    {}
+
+func (m *EmailEvent) GetEmail() *EmailMessage {
+	if m != nil {
+		return m.Email
+	}
+	return nil
+}
+
+func (m *EmailEvent) GetKeepalive() bool {
+	if m != nil {
+		return m.Keepalive
+	}
+	return false
+}
@@ -0,0 +1,333 @@
+// Code generated by protoc-gen-go-grpc from proto/email/v1/email_service.proto.
+// DO NOT EDIT by hand except to keep pace with changes to the .proto file.
+package emailgrpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	EmailService_GetEmail_FullMethodName      = "/email.v1.EmailService/GetEmail"
+	EmailService_ListEmails_FullMethodName    = "/email.v1.EmailService/ListEmails"
+	EmailService_SendEmail_FullMethodName     = "/email.v1.EmailService/SendEmail"
+	EmailService_DeleteEmail_FullMethodName   = "/email.v1.EmailService/DeleteEmail"
+	EmailService_UpdateLabels_FullMethodName  = "/email.v1.EmailService/UpdateLabels"
+	EmailService_MoveToFolder_FullMethodName  = "/email.v1.EmailService/MoveToFolder"
+	EmailService_GetThread_FullMethodName     = "/email.v1.EmailService/GetThread"
+	EmailService_WatchEmails_FullMethodName   = "/email.v1.EmailService/WatchEmails"
+)
+
+// EmailServiceServer is the server API for EmailService.
+type EmailServiceServer interface {
+	GetEmail(context.Context, *GetEmailRequest) (*EmailMessage, error)
+	ListEmails(context.Context, *ListEmailsRequest) (*ListEmailsResponse, error)
+	SendEmail(context.Context, *SendEmailRequest) (*SendEmailResponse, error)
+	DeleteEmail(context.Context, *DeleteEmailRequest) (*DeleteEmailResponse, error)
+	UpdateLabels(context.Context, *UpdateLabelsRequest) (*UpdateLabelsResponse, error)
+	MoveToFolder(context.Context, *MoveToFolderRequest) (*MoveToFolderResponse, error)
+	GetThread(context.Context, *GetThreadRequest) (*ThreadResponse, error)
+	WatchEmails(*WatchEmailsRequest, EmailService_WatchEmailsServer) error
+}
+
+// UnimplementedEmailServiceServer can be embedded in an implementation to
+// get forward-compatible behavior: a handler added to the .proto after an
+// implementation was written returns Unimplemented rather than failing to
+// compile.
+type UnimplementedEmailServiceServer struct{}
+
+func (UnimplementedEmailServiceServer) GetEmail(context.Context, *GetEmailRequest) (*EmailMessage, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetEmail not implemented")
+}
+func (UnimplementedEmailServiceServer) ListEmails(context.Context, *ListEmailsRequest) (*ListEmailsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListEmails not implemented")
+}
+func (UnimplementedEmailServiceServer) SendEmail(context.Context, *SendEmailRequest) (*SendEmailResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SendEmail not implemented")
+}
+func (UnimplementedEmailServiceServer) DeleteEmail(context.Context, *DeleteEmailRequest) (*DeleteEmailResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteEmail not implemented")
+}
+func (UnimplementedEmailServiceServer) UpdateLabels(context.Context, *UpdateLabelsRequest) (*UpdateLabelsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateLabels not implemented")
+}
+func (UnimplementedEmailServiceServer) MoveToFolder(context.Context, *MoveToFolderRequest) (*MoveToFolderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MoveToFolder not implemented")
+}
+func (UnimplementedEmailServiceServer) GetThread(context.Context, *GetThreadRequest) (*ThreadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetThread not implemented")
+}
+func (UnimplementedEmailServiceServer) WatchEmails(*WatchEmailsRequest, EmailService_WatchEmailsServer) error {
+	return status.Error(codes.Unimplemented, "method WatchEmails not implemented")
+}
+
+// EmailService_WatchEmailsServer is the server-side stream handle for the
+// WatchEmails RPC.
+type EmailService_WatchEmailsServer interface {
+	Send(*EmailEvent) error
+	grpc.ServerStream
+}
+
+type emailServiceWatchEmailsServer struct {
+	grpc.ServerStream
+}
+
+func (s *emailServiceWatchEmailsServer) Send(m *EmailEvent) error {
+	return s.ServerStream.SendMsg(m)
+}
+
+// RegisterEmailServiceServer registers srv as the implementation of
+// EmailService on s.
+func RegisterEmailServiceServer(s grpc.ServiceRegistrar, srv EmailServiceServer) {
+	s.RegisterService(&EmailService_ServiceDesc, srv)
+}
+
+func _EmailService_GetEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).GetEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: EmailService_GetEmail_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).GetEmail(ctx, req.(*GetEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailService_ListEmails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEmailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).ListEmails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: EmailService_ListEmails_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).ListEmails(ctx, req.(*ListEmailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailService_SendEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).SendEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: EmailService_SendEmail_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).SendEmail(ctx, req.(*SendEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailService_DeleteEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).DeleteEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: EmailService_DeleteEmail_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).DeleteEmail(ctx, req.(*DeleteEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailService_UpdateLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).UpdateLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: EmailService_UpdateLabels_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).UpdateLabels(ctx, req.(*UpdateLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailService_MoveToFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveToFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).MoveToFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: EmailService_MoveToFolder_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).MoveToFolder(ctx, req.(*MoveToFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailService_GetThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).GetThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: EmailService_GetThread_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).GetThread(ctx, req.(*GetThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailService_WatchEmails_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEmailsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EmailServiceServer).WatchEmails(m, &emailServiceWatchEmailsServer{stream})
+}
+
+// EmailService_ServiceDesc is the grpc.ServiceDesc for EmailService.
+var EmailService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "email.v1.EmailService",
+	HandlerType: (*EmailServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "GetEmail", Handler: _EmailService_GetEmail_Handler},
+		{MethodName: "ListEmails", Handler: _EmailService_ListEmails_Handler},
+		{MethodName: "SendEmail", Handler: _EmailService_SendEmail_Handler},
+		{MethodName: "DeleteEmail", Handler: _EmailService_DeleteEmail_Handler},
+		{MethodName: "UpdateLabels", Handler: _EmailService_UpdateLabels_Handler},
+		{MethodName: "MoveToFolder", Handler: _EmailService_MoveToFolder_Handler},
+		{MethodName: "GetThread", Handler: _EmailService_GetThread_Handler},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEmails",
+			Handler:       _EmailService_WatchEmails_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/email/v1/email_service.proto",
+}
+
+// EmailServiceClient is the client API for EmailService.
+type EmailServiceClient interface {
+	GetEmail(ctx context.Context, in *GetEmailRequest, opts ...grpc.CallOption) (*EmailMessage, error)
+	ListEmails(ctx context.Context, in *ListEmailsRequest, opts ...grpc.CallOption) (*ListEmailsResponse, error)
+	SendEmail(ctx context.Context, in *SendEmailRequest, opts ...grpc.CallOption) (*SendEmailResponse, error)
+	DeleteEmail(ctx context.Context, in *DeleteEmailRequest, opts ...grpc.CallOption) (*DeleteEmailResponse, error)
+	UpdateLabels(ctx context.Context, in *UpdateLabelsRequest, opts ...grpc.CallOption) (*UpdateLabelsResponse, error)
+	MoveToFolder(ctx context.Context, in *MoveToFolderRequest, opts ...grpc.CallOption) (*MoveToFolderResponse, error)
+	GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*ThreadResponse, error)
+	WatchEmails(ctx context.Context, in *WatchEmailsRequest, opts ...grpc.CallOption) (EmailService_WatchEmailsClient, error)
+}
+
+type emailServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewEmailServiceClient returns a client for EmailService backed by cc.
+func NewEmailServiceClient(cc grpc.ClientConnInterface) EmailServiceClient {
+	return &emailServiceClient{cc}
+}
+
+func (c *emailServiceClient) GetEmail(ctx context.Context, in *GetEmailRequest, opts ...grpc.CallOption) (*EmailMessage, error) {
+	out := new(EmailMessage)
+	if err := c.cc.Invoke(ctx, EmailService_GetEmail_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailServiceClient) ListEmails(ctx context.Context, in *ListEmailsRequest, opts ...grpc.CallOption) (*ListEmailsResponse, error) {
+	out := new(ListEmailsResponse)
+	if err := c.cc.Invoke(ctx, EmailService_ListEmails_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailServiceClient) SendEmail(ctx context.Context, in *SendEmailRequest, opts ...grpc.CallOption) (*SendEmailResponse, error) {
+	out := new(SendEmailResponse)
+	if err := c.cc.Invoke(ctx, EmailService_SendEmail_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailServiceClient) DeleteEmail(ctx context.Context, in *DeleteEmailRequest, opts ...grpc.CallOption) (*DeleteEmailResponse, error) {
+	out := new(DeleteEmailResponse)
+	if err := c.cc.Invoke(ctx, EmailService_DeleteEmail_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailServiceClient) UpdateLabels(ctx context.Context, in *UpdateLabelsRequest, opts ...grpc.CallOption) (*UpdateLabelsResponse, error) {
+	out := new(UpdateLabelsResponse)
+	if err := c.cc.Invoke(ctx, EmailService_UpdateLabels_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailServiceClient) MoveToFolder(ctx context.Context, in *MoveToFolderRequest, opts ...grpc.CallOption) (*MoveToFolderResponse, error) {
+	out := new(MoveToFolderResponse)
+	if err := c.cc.Invoke(ctx, EmailService_MoveToFolder_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailServiceClient) GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*ThreadResponse, error) {
+	out := new(ThreadResponse)
+	if err := c.cc.Invoke(ctx, EmailService_GetThread_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailServiceClient) WatchEmails(ctx context.Context, in *WatchEmailsRequest, opts ...grpc.CallOption) (EmailService_WatchEmailsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EmailService_ServiceDesc.Streams[0], EmailService_WatchEmails_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &emailServiceWatchEmailsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// EmailService_WatchEmailsClient is the client-side stream handle for the
+// WatchEmails RPC.
+type EmailService_WatchEmailsClient interface {
+	Recv() (*EmailEvent, error)
+	grpc.ClientStream
+}
+
+type emailServiceWatchEmailsClient struct {
+	grpc.ClientStream
+}
+
+func (x *emailServiceWatchEmailsClient) Recv() (*EmailEvent, error) {
+	m := new(EmailEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
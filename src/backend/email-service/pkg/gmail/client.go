@@ -3,21 +3,45 @@
 package gmail
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
 	"sync"
 	"time"
 
+	"golang.org/x/oauth2" // v0.13.0
 	"golang.org/x/oauth2/google" // v0.13.0
 	"golang.org/x/time/rate" // v0.0.0-20220922220347-f3bd1da661af
 	"google.golang.org/api/gmail/v1" // v0.147.0
+	"google.golang.org/api/googleapi" // v0.147.0
 	"google.golang.org/api/option" // v0.147.0
 
+	"github.com/email-management-platform/backend/email-service/internal/breaker"
+	"github.com/email-management-platform/backend/email-service/internal/config"
 	"github.com/email-management-platform/backend/email-service/internal/models"
+	"github.com/email-management-platform/backend/email-service/pkg/provider"
+	"github.com/email-management-platform/backend/email-service/pkg/pushsync"
+	"github.com/email-management-platform/backend/email-service/pkg/tokens"
 )
 
+// breakerProvider is this client's provider label when keying into a
+// breaker.Registry.
+const breakerProvider = "gmail"
+
+// ErrHistoryExpired is returned by SyncChanges when the requested historyId
+// has fallen out of Gmail's retention window (currently ~7 days) and the
+// caller must fall back to a full resync instead of an incremental one.
+var ErrHistoryExpired = errors.New("gmail: history expired, full resync required")
+
 // Default configuration values
 const (
 	defaultTimeout = 30 * time.Second
@@ -26,6 +50,10 @@ const (
 	rateLimit = 250
 	// Batch size for listing emails
 	defaultBatchSize = 100
+	// batchAPIURL is the Gmail multipart/mixed batch endpoint
+	batchAPIURL = "https://www.googleapis.com/batch/gmail/v1"
+	// maxBatchRequestSize is the number of sub-requests Gmail allows per batch call
+	maxBatchRequestSize = 100
 )
 
 // Gmail API scopes required for the client
@@ -42,6 +70,11 @@ type ClientOptions struct {
 	RateLimit   float64
 	MaxRetries  int
 	MetricsHost string
+
+	// Breakers is consulted for per-operation circuit breaking and
+	// slow-start page sizing. Nil uses a Registry built from
+	// config.DefaultProviderBreakerConfig.
+	Breakers *breaker.Registry
 }
 
 // GmailClient provides a thread-safe Gmail API client with enhanced features
@@ -50,11 +83,15 @@ type GmailClient struct {
 	oauthConfig     *oauth2.Config
 	userEmail       string
 	rateLimiter     *rate.Limiter
+	breakers        *breaker.Registry
 	metricsReporter MetricsReporter
 	tokenManager    *TokenManager
 	mu             sync.RWMutex
 }
 
+// GmailClient implements provider.EmailProvider.
+var _ provider.EmailProvider = (*GmailClient)(nil)
+
 // MetricsReporter defines the interface for reporting client metrics
 type MetricsReporter interface {
 	ReportAPICall(method string, duration time.Duration, err error)
@@ -62,15 +99,74 @@ type MetricsReporter interface {
 	ReportTokenRefresh(success bool, err error)
 }
 
-// TokenManager handles OAuth token management and refresh
+// TokenManager loads and persists OAuth2 tokens through a pluggable
+// tokens.Store, implementing oauth2.TokenSource so it can be handed
+// directly to the Gmail API client.
 type TokenManager struct {
+	store           tokens.Store
+	userID          string
+	config          *oauth2.Config
+	metricsReporter MetricsReporter
+
 	token       *oauth2.Token
-	config      *oauth2.Config
 	refreshLock sync.Mutex
 }
 
-// NewGmailClient creates a new Gmail client with the provided credentials and options
-func NewGmailClient(ctx context.Context, credentialsJSON string, opts *ClientOptions) (*GmailClient, error) {
+// Token implements oauth2.TokenSource. It lazily loads the persisted token
+// on first use, refreshes it through the wrapped oauth2.Config, and writes
+// the result back to the store whenever the access token changed.
+func (tm *TokenManager) Token() (*oauth2.Token, error) {
+	tm.refreshLock.Lock()
+	defer tm.refreshLock.Unlock()
+
+	if tm.token == nil {
+		loaded, err := tm.store.Get(tm.userID)
+		if err != nil && !errors.Is(err, tokens.ErrNotFound) {
+			return nil, fmt.Errorf("failed to load token for %s: %w", tm.userID, err)
+		}
+		tm.token = loaded
+	}
+
+	previous := tm.token
+	refreshed, err := tm.config.TokenSource(context.Background(), tm.token).Token()
+	if tm.metricsReporter != nil {
+		tm.metricsReporter.ReportTokenRefresh(err == nil, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token for %s: %w", tm.userID, err)
+	}
+
+	if previous == nil || refreshed.AccessToken != previous.AccessToken {
+		if err := tm.store.Put(tm.userID, refreshed); err != nil {
+			return nil, fmt.Errorf("failed to persist refreshed token for %s: %w", tm.userID, err)
+		}
+	}
+
+	tm.token = refreshed
+	return refreshed, nil
+}
+
+// Authorize completes the three-legged OAuth2 flow by exchanging an
+// authorization code for a token and persisting it to the store.
+func (tm *TokenManager) Authorize(ctx context.Context, code string) error {
+	tm.refreshLock.Lock()
+	defer tm.refreshLock.Unlock()
+
+	token, err := tm.config.Exchange(ctx, code)
+	if err != nil {
+		return fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	if err := tm.store.Put(tm.userID, token); err != nil {
+		return fmt.Errorf("failed to persist authorized token: %w", err)
+	}
+	tm.token = token
+	return nil
+}
+
+// NewGmailClient creates a new Gmail client with the provided credentials,
+// a token store for persisting the given userID's OAuth2 token across
+// restarts, and client options.
+func NewGmailClient(ctx context.Context, credentialsJSON string, store tokens.Store, userID string, opts *ClientOptions) (*GmailClient, error) {
 	if opts == nil {
 		opts = &ClientOptions{
 			Timeout:    defaultTimeout,
@@ -79,33 +175,49 @@ func NewGmailClient(ctx context.Context, credentialsJSON string, opts *ClientOpt
 		}
 	}
 
+	breakers := opts.Breakers
+	if breakers == nil {
+		defaults := config.DefaultProviderBreakerConfig()
+		breakers = breaker.NewRegistry(config.BreakerConfig{
+			Gmail:    defaults,
+			Outlook:  defaults,
+			Database: defaults,
+		})
+	}
+
 	// Parse OAuth2 credentials
 	config, err := google.ConfigFromJSON([]byte(credentialsJSON), gmailScopes...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse OAuth2 credentials: %w", err)
 	}
 
+	// Initialize metrics reporter
+	metricsReporter := NewDefaultMetricsReporter(opts.MetricsHost)
+
 	// Initialize token manager
 	tokenManager := &TokenManager{
-		config: config,
+		store:           store,
+		userID:          userID,
+		config:          config,
+		metricsReporter: metricsReporter,
 	}
 
-	// Create Gmail service with retry options
+	// Create Gmail service, using the token manager itself as the token
+	// source so refreshes flow through the store.
 	service, err := gmail.NewService(ctx,
-		option.WithTokenSource(config.TokenSource(ctx, tokenManager.token)),
+		option.WithTokenSource(oauth2.ReuseTokenSource(nil, tokenManager)),
 		option.WithScopes(gmailScopes...),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Gmail service: %w", err)
 	}
 
-	// Initialize metrics reporter
-	metricsReporter := NewDefaultMetricsReporter(opts.MetricsHost)
-
 	client := &GmailClient{
 		service:         service,
 		oauthConfig:     config,
+		userEmail:       userID,
 		rateLimiter:     rate.NewLimiter(rate.Limit(opts.RateLimit), 1),
+		breakers:        breakers,
 		metricsReporter: metricsReporter,
 		tokenManager:    tokenManager,
 	}
@@ -114,7 +226,7 @@ func NewGmailClient(ctx context.Context, credentialsJSON string, opts *ClientOpt
 }
 
 // GetEmail retrieves a single email by ID with enhanced thread tracking
-func (c *GmailClient) GetEmail(ctx context.Context, messageID string, opts *GetEmailOptions) (*models.Email, error) {
+func (c *GmailClient) GetEmail(ctx context.Context, messageID string) (*models.Email, error) {
 	if err := c.rateLimiter.Wait(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
@@ -178,6 +290,557 @@ func (c *GmailClient) GetEmail(ctx context.Context, messageID string, opts *GetE
 	return email, nil
 }
 
+// SyncChanges retrieves mailbox changes since cursor (a Gmail historyId)
+// using the History API, avoiding a full re-list of messages. On the first
+// call, when cursor is empty, it performs a full Messages.List and returns
+// the mailbox's current historyId as the new cursor. If the requested
+// historyId has aged out of Gmail's retention window, it returns
+// ErrHistoryExpired so the caller can trigger a full resync.
+func (c *GmailClient) SyncChanges(ctx context.Context, cursor string) ([]*models.EmailChange, string, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, "", fmt.Errorf("rate limit exceeded: %w", err)
+	}
+
+	start := time.Now()
+	var reportErr error
+	defer func() {
+		c.metricsReporter.ReportAPICall("sync_changes", time.Since(start), reportErr)
+	}()
+
+	if cursor == "" {
+		changes, nextCursor, err := c.fullResync(ctx)
+		reportErr = err
+		return changes, nextCursor, err
+	}
+
+	changes, nextCursor, err := c.incrementalSync(ctx, cursor)
+	if err != nil {
+		if isHistoryExpired(err) {
+			c.metricsReporter.ReportAPICall("sync_full_resync", 0, nil)
+			reportErr = ErrHistoryExpired
+			return nil, "", ErrHistoryExpired
+		}
+		reportErr = err
+		return nil, "", fmt.Errorf("failed to sync changes: %w", err)
+	}
+
+	return changes, nextCursor, nil
+}
+
+// fullResync lists every message in the mailbox and captures the current
+// profile historyId to seed future incremental syncs.
+func (c *GmailClient) fullResync(ctx context.Context) ([]*models.EmailChange, string, error) {
+	profile, err := c.service.Users.GetProfile("me").Context(ctx).Do()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	var changes []*models.EmailChange
+	pageToken := ""
+	for {
+		call := c.service.Users.Messages.List("me").Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to list messages: %w", err)
+		}
+
+		for _, msg := range resp.Messages {
+			changes = append(changes, &models.EmailChange{
+				MessageID: msg.Id,
+				Change:    models.ChangeAdded,
+			})
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	return changes, formatHistoryID(profile.HistoryId), nil
+}
+
+// incrementalSync pages through the History API starting at cursor and
+// translates history records into EmailChange values.
+func (c *GmailClient) incrementalSync(ctx context.Context, cursor string) ([]*models.EmailChange, string, error) {
+	startHistoryID, err := parseHistoryID(cursor)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var changes []*models.EmailChange
+	latestHistoryID := startHistoryID
+	pageToken := ""
+
+	for {
+		call := c.service.Users.History.List("me").StartHistoryId(startHistoryID).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, h := range resp.History {
+			if h.Id > latestHistoryID {
+				latestHistoryID = h.Id
+			}
+			for _, added := range h.MessagesAdded {
+				changes = append(changes, &models.EmailChange{
+					MessageID: added.Message.Id,
+					Change:    models.ChangeAdded,
+				})
+			}
+			for _, deleted := range h.MessagesDeleted {
+				changes = append(changes, &models.EmailChange{
+					MessageID: deleted.Message.Id,
+					Change:    models.ChangeDeleted,
+				})
+			}
+			for _, added := range h.LabelsAdded {
+				changes = append(changes, &models.EmailChange{
+					MessageID: added.Message.Id,
+					Change:    models.ChangeLabelChanged,
+				})
+			}
+			for _, removed := range h.LabelsRemoved {
+				changes = append(changes, &models.EmailChange{
+					MessageID: removed.Message.Id,
+					Change:    models.ChangeLabelChanged,
+				})
+			}
+		}
+
+		if resp.HistoryId > latestHistoryID {
+			latestHistoryID = resp.HistoryId
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	return changes, formatHistoryID(latestHistoryID), nil
+}
+
+// isHistoryExpired reports whether err represents Gmail's 404 response for
+// a historyId that has aged out of the retention window.
+func isHistoryExpired(err error) bool {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == 404
+	}
+	return false
+}
+
+// ListEmails lists messages in the mailbox, satisfying provider.EmailProvider.
+// It resolves only message IDs from the Gmail List API and then hydrates
+// them in bulk via GetEmailsBatch to minimize round trips.
+func (c *GmailClient) ListEmails(ctx context.Context, opts provider.ListOptions) (*provider.Page, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limit exceeded: %w", err)
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultBatchSize
+	}
+
+	// Slow-start: immediately after the list_emails breaker recovers from a
+	// trip, shrink the page size for a few calls rather than resuming at
+	// full throughput straight away.
+	key := breaker.Key{Provider: breakerProvider, Operation: "list_emails"}
+	pageSize = c.breakers.PageSize(key, pageSize)
+
+	var resp *gmail.ListMessagesResponse
+	_, err := c.breakers.Execute(key, func() (interface{}, error) {
+		call := c.service.Users.Messages.List("me").MaxResults(int64(pageSize)).Context(ctx)
+		if opts.FolderID != "" {
+			call = call.LabelIds(opts.FolderID)
+		}
+		if opts.PageToken != "" {
+			call = call.PageToken(opts.PageToken)
+		}
+
+		listResp, listErr := call.Do()
+		if listErr != nil {
+			return nil, listErr
+		}
+		resp = listResp
+		return nil, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list messages: %w", err)
+	}
+
+	ids := make([]string, len(resp.Messages))
+	for i, msg := range resp.Messages {
+		ids[i] = msg.Id
+	}
+
+	emails, err := c.GetEmailsBatch(ctx, ids)
+	if err != nil {
+		var batchErr *models.BatchError
+		if !errors.As(err, &batchErr) {
+			return nil, fmt.Errorf("failed to hydrate listed messages: %w", err)
+		}
+	}
+
+	return &provider.Page{Emails: emails, NextPageToken: resp.NextPageToken}, nil
+}
+
+// SendEmail sends a new message via Users.Messages.Send, building an RFC
+// 5322 MIME body that is base64url-encoded into the Raw field.
+func (c *GmailClient) SendEmail(ctx context.Context, msg *models.OutgoingEmail) (string, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return "", fmt.Errorf("rate limit exceeded: %w", err)
+	}
+
+	raw, err := buildRFC5322Message(msg, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to build message: %w", err)
+	}
+
+	start := time.Now()
+	sent, err := c.service.Users.Messages.Send("me", &gmail.Message{Raw: raw}).Context(ctx).Do()
+	c.metricsReporter.ReportAPICall("send_email", time.Since(start), err)
+	if err != nil {
+		return "", fmt.Errorf("failed to send email: %w", err)
+	}
+	return sent.Id, nil
+}
+
+// ReplyEmail sends msg as a reply within threadID.
+func (c *GmailClient) ReplyEmail(ctx context.Context, threadID string, msg *models.OutgoingEmail) (string, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return "", fmt.Errorf("rate limit exceeded: %w", err)
+	}
+
+	raw, err := buildRFC5322Message(msg, threadID)
+	if err != nil {
+		return "", fmt.Errorf("failed to build reply message: %w", err)
+	}
+
+	start := time.Now()
+	sent, err := c.service.Users.Messages.Send("me", &gmail.Message{Raw: raw, ThreadId: threadID}).Context(ctx).Do()
+	c.metricsReporter.ReportAPICall("reply_email", time.Since(start), err)
+	if err != nil {
+		return "", fmt.Errorf("failed to send reply: %w", err)
+	}
+	return sent.Id, nil
+}
+
+// ModifyLabels adds and removes Gmail labels from a message.
+func (c *GmailClient) ModifyLabels(ctx context.Context, id string, add, remove []string) error {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limit exceeded: %w", err)
+	}
+
+	req := &gmail.ModifyMessageRequest{
+		AddLabelIds:    add,
+		RemoveLabelIds: remove,
+	}
+
+	start := time.Now()
+	_, err := c.service.Users.Messages.Modify("me", id, req).Context(ctx).Do()
+	c.metricsReporter.ReportAPICall("modify_labels", time.Since(start), err)
+	if err != nil {
+		return fmt.Errorf("failed to modify labels: %w", err)
+	}
+	return nil
+}
+
+// GetAttachment streams a single attachment's decoded bytes.
+func (c *GmailClient) GetAttachment(ctx context.Context, messageID, attachmentID string) (io.ReadCloser, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limit exceeded: %w", err)
+	}
+
+	att, err := c.service.Users.Messages.Attachments.Get("me", messageID, attachmentID).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	data, err := base64.URLEncoding.DecodeString(att.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode attachment data: %w", err)
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// buildRFC5322Message renders msg into a base64url-encoded RFC 5322 MIME
+// message suitable for the Gmail Raw field. When inReplyTo is non-empty the
+// message is annotated as a reply.
+func buildRFC5322Message(msg *models.OutgoingEmail, inReplyTo string) (string, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", msg.FromAddress)
+	fmt.Fprintf(&buf, "To: %s\r\n", joinAddresses(msg.ToAddresses))
+	if len(msg.CCAddresses) > 0 {
+		fmt.Fprintf(&buf, "Cc: %s\r\n", joinAddresses(msg.CCAddresses))
+	}
+	fmt.Fprintf(&buf, "Subject: %s\r\n", msg.Subject)
+	if inReplyTo != "" {
+		fmt.Fprintf(&buf, "In-Reply-To: %s\r\n", inReplyTo)
+		fmt.Fprintf(&buf, "References: %s\r\n", inReplyTo)
+	}
+	for k, v := range msg.Headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", k, v)
+	}
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n\r\n")
+	buf.WriteString(msg.TextBody)
+
+	return base64.URLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func joinAddresses(addrs []string) string {
+	result := ""
+	for i, a := range addrs {
+		if i > 0 {
+			result += ", "
+		}
+		result += a
+	}
+	return result
+}
+
+// GetEmailsBatch retrieves multiple messages in as few round trips as
+// possible using the Gmail batch endpoint, chunking automatically when ids
+// exceeds maxBatchRequestSize. Per-item failures are collected into a
+// *models.BatchError rather than failing the whole call.
+func (c *GmailClient) GetEmailsBatch(ctx context.Context, ids []string) ([]*models.Email, error) {
+	start := time.Now()
+	var reportErr error
+	defer func() {
+		c.metricsReporter.ReportAPICall("get_emails_batch", time.Since(start), reportErr)
+	}()
+
+	results := make([]*models.Email, len(ids))
+	batchErr := &models.BatchError{Errors: make(map[int]error)}
+
+	for offset := 0; offset < len(ids); offset += maxBatchRequestSize {
+		end := offset + maxBatchRequestSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunk := ids[offset:end]
+
+		// One token per sub-request, taken one at a time: WaitN fails
+		// outright when n exceeds the limiter's burst of 1.
+		for range chunk {
+			if err := c.rateLimiter.Wait(ctx); err != nil {
+				reportErr = err
+				return nil, fmt.Errorf("rate limit exceeded: %w", err)
+			}
+		}
+
+		chunkResults, chunkErrs, err := c.executeBatchChunk(ctx, chunk)
+		if err != nil {
+			reportErr = err
+			return nil, fmt.Errorf("batch request failed: %w", err)
+		}
+
+		for i, email := range chunkResults {
+			results[offset+i] = email
+		}
+		for i, itemErr := range chunkErrs {
+			batchErr.Errors[offset+i] = itemErr
+		}
+	}
+
+	if len(batchErr.Errors) > 0 {
+		reportErr = batchErr
+		return results, batchErr
+	}
+	return results, nil
+}
+
+// executeBatchChunk builds and sends a single multipart/mixed batch request
+// containing up to maxBatchRequestSize GET sub-requests, and parses the
+// multipart response back into ordered results.
+func (c *GmailClient) executeBatchChunk(ctx context.Context, ids []string) ([]*models.Email, map[int]error, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for i, id := range ids {
+		partHeader := textproto.MIMEHeader{}
+		partHeader.Set("Content-Type", "application/http")
+		partHeader.Set("Content-ID", fmt.Sprintf("<item%d>", i))
+
+		part, err := writer.CreatePart(partHeader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create batch part: %w", err)
+		}
+		fmt.Fprintf(part, "GET /gmail/v1/users/me/messages/%s?format=full\r\n", id)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to close batch writer: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, batchAPIURL, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build batch request: %w", err)
+	}
+	req.Header.Set("Content-Type", "multipart/mixed; boundary="+writer.Boundary())
+
+	httpClient := oauth2.NewClient(ctx, c.tokenManager)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("batch request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse batch response content-type: %w", err)
+	}
+
+	reader := multipart.NewReader(resp.Body, params["boundary"])
+	results := make([]*models.Email, len(ids))
+	errs := make(map[int]error)
+
+	for i := 0; ; i++ {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+
+		statusLine, httpResp, err := parseBatchSubResponse(part)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		if statusLine >= 300 {
+			errs[i] = fmt.Errorf("sub-request %d failed with status %d", i, statusLine)
+			continue
+		}
+
+		var msg gmail.Message
+		if err := json.NewDecoder(bufio.NewReader(bytes.NewReader(httpResp))).Decode(&msg); err != nil {
+			errs[i] = fmt.Errorf("failed to decode sub-response %d: %w", i, err)
+			continue
+		}
+		if i < len(results) {
+			results[i] = convertMessage(&msg, c.userEmail)
+		}
+	}
+
+	return results, errs, nil
+}
+
+// parseBatchSubResponse parses a single application/http sub-response part,
+// returning its HTTP status code and raw JSON body.
+func parseBatchSubResponse(part *multipart.Part) (int, []byte, error) {
+	tp := textproto.NewReader(bufio.NewReader(part))
+	statusLine, err := tp.ReadLine()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read sub-response status line: %w", err)
+	}
+
+	var status int
+	if _, err := fmt.Sscanf(statusLine, "HTTP/1.1 %d", &status); err != nil {
+		return 0, nil, fmt.Errorf("failed to parse sub-response status: %w", err)
+	}
+
+	if _, err := tp.ReadMIMEHeader(); err != nil {
+		return 0, nil, fmt.Errorf("failed to read sub-response headers: %w", err)
+	}
+
+	bodyBytes := &bytes.Buffer{}
+	if _, err := bodyBytes.ReadFrom(tp.R); err != nil {
+		return 0, nil, fmt.Errorf("failed to read sub-response body: %w", err)
+	}
+
+	return status, bodyBytes.Bytes(), nil
+}
+
+// convertMessage adapts a raw gmail.Message (as returned from a batch
+// sub-response) into the internal Email model.
+func convertMessage(msg *gmail.Message, userEmail string) *models.Email {
+	email := &models.Email{
+		MessageID:      msg.Id,
+		ThreadID:       msg.ThreadId,
+		ConversationID: msg.ThreadId,
+		AccountID:      userEmail,
+		Labels:         msg.LabelIds,
+		Status:         convertGmailStatus(msg.LabelIds),
+	}
+	if msg.Payload != nil {
+		email.Subject = getHeader(msg.Payload.Headers, "Subject")
+		email.FromAddress = getHeader(msg.Payload.Headers, "From")
+		email.ToAddresses = parseAddresses(getHeader(msg.Payload.Headers, "To"))
+		email.Headers = convertHeaders(msg.Payload.Headers)
+		email.Content = extractContent(msg.Payload)
+	}
+	return email
+}
+
+// Watch registers a Gmail Pub/Sub watch on the authenticated mailbox so that
+// server-initiated notifications arrive instead of requiring polling. The
+// caller is expected to run a Pub/Sub subscriber against opts.Topic and feed
+// received messages into pushsync.Handler.
+func (c *GmailClient) Watch(ctx context.Context, opts pushsync.WatchOptions) (*pushsync.Subscription, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limit exceeded: %w", err)
+	}
+
+	start := time.Now()
+	req := &gmail.WatchRequest{
+		TopicName: opts.Topic,
+		LabelIds:  opts.LabelFilter,
+	}
+
+	resp, err := c.service.Users.Watch("me", req).Context(ctx).Do()
+	c.metricsReporter.ReportAPICall("watch", time.Since(start), err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gmail watch: %w", err)
+	}
+
+	return &pushsync.Subscription{
+		ID:        formatHistoryID(resp.HistoryId),
+		Provider:  pushsync.ProviderGmail,
+		ExpiresAt: time.UnixMilli(resp.Expiration),
+		HistoryID: formatHistoryID(resp.HistoryId),
+	}, nil
+}
+
+// Unwatch cancels the mailbox's active Gmail watch, stopping further push
+// notifications.
+func (c *GmailClient) Unwatch(ctx context.Context, sub *pushsync.Subscription) error {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limit exceeded: %w", err)
+	}
+
+	start := time.Now()
+	err := c.service.Users.Stop("me").Context(ctx).Do()
+	c.metricsReporter.ReportAPICall("unwatch", time.Since(start), err)
+	if err != nil {
+		return fmt.Errorf("failed to stop gmail watch: %w", err)
+	}
+	return nil
+}
+
+func formatHistoryID(id uint64) string {
+	return fmt.Sprintf("%d", id)
+}
+
+func parseHistoryID(cursor string) (uint64, error) {
+	var id uint64
+	if _, err := fmt.Sscanf(cursor, "%d", &id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
 // Helper functions
 
 func isRetryableError(err error) bool {
@@ -3,24 +3,35 @@
 package outlook
 
 import (
+    "bytes"
     "context"
+    "encoding/base64"
     "encoding/json"
     "errors"
     "fmt"
+    "io"
     "net/http"
+    "strconv"
     "sync"
     "time"
 
     "github.com/microsoftgraph/msgraph-sdk-go" // v1.20.0
     "golang.org/x/oauth2" // v0.13.0
     "golang.org/x/time/rate" // v0.3.0
-    "github.com/sony/gobreaker" // v0.5.0
     "github.com/prometheus/client_golang/prometheus" // v1.17.0
 
+    "github.com/email-management-platform/backend/email-service/internal/breaker"
     "github.com/email-management-platform/backend/email-service/internal/config"
     "github.com/email-management-platform/backend/email-service/internal/models"
+    "github.com/email-management-platform/backend/email-service/pkg/provider"
+    "github.com/email-management-platform/backend/email-service/pkg/pushsync"
+    "github.com/email-management-platform/backend/email-service/pkg/tokens"
 )
 
+// breakerProvider is this client's provider label when keying into a
+// breaker.Registry.
+const breakerProvider = "outlook"
+
 const (
     // API endpoints and configuration
     graphBaseURL = "https://graph.microsoft.com/v1.0"
@@ -31,19 +42,27 @@ const (
     // Rate limiting configuration
     rateLimit = 100
     rateBurst = 10
+
+    // maxBatchRequestSize is the number of sub-requests Graph allows per $batch call
+    maxBatchRequestSize = 20
 )
 
 // Client represents an enhanced Outlook email client with security and monitoring features
 type Client struct {
-    graphClient    *msgraph.GraphServiceClient
-    oauthConfig    *oauth2.Config
-    config         *config.OutlookConfig
-    rateLimiter    *rate.Limiter
-    circuitBreaker *gobreaker.CircuitBreaker
-    metrics        *clientMetrics
-    mu            sync.RWMutex
+    graphClient *msgraph.GraphServiceClient
+    httpClient  *http.Client
+    oauthConfig *oauth2.Config
+    config      *config.OutlookConfig
+    rateLimiter *rate.Limiter
+    breakers    *breaker.Registry
+    tokenManager *tokenManager
+    metrics     *clientMetrics
+    mu          sync.RWMutex
 }
 
+// Client implements provider.EmailProvider.
+var _ provider.EmailProvider = (*Client)(nil)
+
 // clientMetrics holds Prometheus metrics for monitoring
 type clientMetrics struct {
     requestCounter *prometheus.CounterVec
@@ -51,8 +70,70 @@ type clientMetrics struct {
     errorCounter   *prometheus.CounterVec
 }
 
-// NewClient creates a new Outlook client instance with enhanced security and monitoring
-func NewClient(cfg *config.OutlookConfig) (*Client, error) {
+// tokenManager loads and persists this client's OAuth2 token through a
+// pluggable tokens.Store, implementing oauth2.TokenSource.
+type tokenManager struct {
+    store   tokens.Store
+    userID  string
+    config  *oauth2.Config
+    metrics *clientMetrics
+
+    token       *oauth2.Token
+    refreshLock sync.Mutex
+}
+
+// Token implements oauth2.TokenSource.
+func (tm *tokenManager) Token() (*oauth2.Token, error) {
+    tm.refreshLock.Lock()
+    defer tm.refreshLock.Unlock()
+
+    if tm.token == nil {
+        loaded, err := tm.store.Get(tm.userID)
+        if err != nil && !errors.Is(err, tokens.ErrNotFound) {
+            return nil, fmt.Errorf("failed to load token for %s: %w", tm.userID, err)
+        }
+        tm.token = loaded
+    }
+
+    previous := tm.token
+    refreshed, err := tm.config.TokenSource(context.Background(), tm.token).Token()
+    if err != nil {
+        tm.metrics.errorCounter.WithLabelValues("token_refresh").Inc()
+        return nil, fmt.Errorf("failed to refresh token for %s: %w", tm.userID, err)
+    }
+
+    if previous == nil || refreshed.AccessToken != previous.AccessToken {
+        if err := tm.store.Put(tm.userID, refreshed); err != nil {
+            return nil, fmt.Errorf("failed to persist refreshed token for %s: %w", tm.userID, err)
+        }
+    }
+
+    tm.token = refreshed
+    return refreshed, nil
+}
+
+// Authorize completes the three-legged OAuth2 flow by exchanging an
+// authorization code for a token and persisting it to the store.
+func (tm *tokenManager) Authorize(ctx context.Context, code string) error {
+    tm.refreshLock.Lock()
+    defer tm.refreshLock.Unlock()
+
+    token, err := tm.config.Exchange(ctx, code)
+    if err != nil {
+        return fmt.Errorf("failed to exchange authorization code: %w", err)
+    }
+    if err := tm.store.Put(tm.userID, token); err != nil {
+        return fmt.Errorf("failed to persist authorized token: %w", err)
+    }
+    tm.token = token
+    return nil
+}
+
+// NewClient creates a new Outlook client instance, persisting userID's
+// OAuth2 token through store so the three-legged flow survives restarts.
+// breakers may be nil, in which case a Registry built from
+// config.DefaultProviderBreakerConfig is used.
+func NewClient(ctx context.Context, cfg *config.OutlookConfig, store tokens.Store, userID string, breakers *breaker.Registry) (*Client, error) {
     if err := validateConfig(cfg); err != nil {
         return nil, fmt.Errorf("invalid config: %w", err)
     }
@@ -72,22 +153,22 @@ func NewClient(cfg *config.OutlookConfig) (*Client, error) {
     // Initialize metrics
     metrics := initializeMetrics()
 
-    // Initialize circuit breaker
-    cb := gobreaker.NewCircuitBreaker(gobreaker.Settings{
-        Name:        "outlook-api",
-        MaxRequests: 5,
-        Interval:    10 * time.Second,
-        Timeout:     30 * time.Second,
-        ReadyToTrip: func(counts gobreaker.Counts) bool {
-            failureRatio := float64(counts.TotalFailures) / float64(counts.Requests)
-            return counts.Requests >= 10 && failureRatio >= 0.6
-        },
-        OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
-            if to == gobreaker.StateOpen {
-                metrics.errorCounter.WithLabelValues("circuit_breaker_open").Inc()
-            }
-        },
-    })
+    // Initialize token manager
+    tokenMgr := &tokenManager{
+        store:   store,
+        userID:  userID,
+        config:  oauthConfig,
+        metrics: metrics,
+    }
+
+    if breakers == nil {
+        defaults := config.DefaultProviderBreakerConfig()
+        breakers = breaker.NewRegistry(config.BreakerConfig{
+            Gmail:    defaults,
+            Outlook:  defaults,
+            Database: defaults,
+        })
+    }
 
     // Create Microsoft Graph client
     graphClient, err := msgraph.NewGraphServiceClient()
@@ -96,12 +177,14 @@ func NewClient(cfg *config.OutlookConfig) (*Client, error) {
     }
 
     return &Client{
-        graphClient:    graphClient,
-        oauthConfig:    oauthConfig,
-        config:         cfg,
-        rateLimiter:    rate.NewLimiter(rate.Limit(rateLimit), rateBurst),
-        circuitBreaker: cb,
-        metrics:        metrics,
+        graphClient:  graphClient,
+        httpClient:   &http.Client{Timeout: defaultTimeout, Transport: &oauth2.Transport{Source: oauth2.ReuseTokenSource(nil, tokenMgr), Base: http.DefaultTransport}},
+        oauthConfig:  oauthConfig,
+        config:       cfg,
+        rateLimiter:  rate.NewLimiter(rate.Limit(rateLimit), rateBurst),
+        breakers:     breakers,
+        tokenManager: tokenMgr,
+        metrics:      metrics,
     }, nil
 }
 
@@ -115,6 +198,12 @@ func (c *Client) GetEmails(ctx context.Context, folderID string, pageSize int, p
     timer := prometheus.NewTimer(c.metrics.requestLatency.WithLabelValues("get_emails"))
     defer timer.ObserveDuration()
 
+    // Slow-start: immediately after the get_emails breaker recovers from a
+    // trip, shrink the page size for a few calls rather than resuming at
+    // full throughput straight away.
+    key := breaker.Key{Provider: breakerProvider, Operation: "get_emails"}
+    pageSize = c.breakers.PageSize(key, pageSize)
+
     // Execute request with circuit breaker
     var emails []*models.Email
     var nextPageToken string
@@ -128,7 +217,7 @@ func (c *Client) GetEmails(ctx context.Context, folderID string, pageSize int, p
         }
     }
 
-    err := c.executeWithRetry(ctx, operation)
+    err := c.executeWithRetry(ctx, "get_emails", operation)
     if err != nil {
         c.metrics.errorCounter.WithLabelValues("get_emails").Inc()
         return nil, "", fmt.Errorf("failed to get emails: %w", err)
@@ -138,6 +227,197 @@ func (c *Client) GetEmails(ctx context.Context, folderID string, pageSize int, p
     return emails, nextPageToken, nil
 }
 
+// GetEmail retrieves a single message by ID, satisfying provider.EmailProvider.
+func (c *Client) GetEmail(ctx context.Context, id string) (*models.Email, error) {
+    if err := c.rateLimiter.Wait(ctx); err != nil {
+        return nil, fmt.Errorf("rate limit exceeded: %w", err)
+    }
+
+    timer := prometheus.NewTimer(c.metrics.requestLatency.WithLabelValues("get_email"))
+    defer timer.ObserveDuration()
+
+    var msg msgraph.Message
+    operation := func() error {
+        return c.getJSON(ctx, fmt.Sprintf("/me/messages/%s", id), &msg)
+    }
+    if err := c.executeWithRetry(ctx, "get_email", operation); err != nil {
+        c.metrics.errorCounter.WithLabelValues("get_email").Inc()
+        return nil, fmt.Errorf("failed to get email: %w", err)
+    }
+
+    c.metrics.requestCounter.WithLabelValues("get_email").Inc()
+    return convertToEmail(&msg), nil
+}
+
+// ListEmails lists messages, satisfying provider.EmailProvider, by adapting
+// the provider-native GetEmails call.
+func (c *Client) ListEmails(ctx context.Context, opts provider.ListOptions) (*provider.Page, error) {
+    emails, nextPageToken, err := c.GetEmails(ctx, opts.FolderID, opts.PageSize, opts.PageToken)
+    if err != nil {
+        return nil, err
+    }
+    return &provider.Page{Emails: emails, NextPageToken: nextPageToken}, nil
+}
+
+// sendMailRequest is the JSON body posted to /me/sendMail.
+type sendMailRequest struct {
+    Message         graphOutgoingMessage `json:"message"`
+    SaveToSentItems bool                 `json:"saveToSentItems"`
+}
+
+type graphOutgoingMessage struct {
+    Subject      string                `json:"subject"`
+    Body         graphItemBody         `json:"body"`
+    ToRecipients []graphRecipient      `json:"toRecipients"`
+    CcRecipients []graphRecipient      `json:"ccRecipients,omitempty"`
+}
+
+type graphItemBody struct {
+    ContentType string `json:"contentType"`
+    Content     string `json:"content"`
+}
+
+type graphRecipient struct {
+    EmailAddress graphEmailAddress `json:"emailAddress"`
+}
+
+type graphEmailAddress struct {
+    Address string `json:"address"`
+}
+
+// SendEmail sends a new message via POST /me/sendMail.
+func (c *Client) SendEmail(ctx context.Context, msg *models.OutgoingEmail) (string, error) {
+    if err := c.rateLimiter.Wait(ctx); err != nil {
+        return "", fmt.Errorf("rate limit exceeded: %w", err)
+    }
+
+    req := sendMailRequest{Message: toGraphMessage(msg), SaveToSentItems: true}
+
+    operation := func() error {
+        return c.postJSON(ctx, "/me/sendMail", req, nil)
+    }
+    if err := c.executeWithRetry(ctx, "send_email", operation); err != nil {
+        c.metrics.errorCounter.WithLabelValues("send_email").Inc()
+        return "", fmt.Errorf("failed to send email: %w", err)
+    }
+
+    c.metrics.requestCounter.WithLabelValues("send_email").Inc()
+    // /me/sendMail returns 202 Accepted with no body, so Graph does not hand
+    // back a message ID synchronously.
+    return "", nil
+}
+
+// ReplyEmail sends msg as a reply to an existing message via
+// POST /me/messages/{id}/reply.
+func (c *Client) ReplyEmail(ctx context.Context, threadID string, msg *models.OutgoingEmail) (string, error) {
+    if err := c.rateLimiter.Wait(ctx); err != nil {
+        return "", fmt.Errorf("rate limit exceeded: %w", err)
+    }
+
+    req := map[string]interface{}{"comment": msg.TextBody}
+
+    operation := func() error {
+        return c.postJSON(ctx, fmt.Sprintf("/me/messages/%s/reply", threadID), req, nil)
+    }
+    if err := c.executeWithRetry(ctx, "reply_email", operation); err != nil {
+        c.metrics.errorCounter.WithLabelValues("reply_email").Inc()
+        return "", fmt.Errorf("failed to send reply: %w", err)
+    }
+
+    c.metrics.requestCounter.WithLabelValues("reply_email").Inc()
+    return "", nil
+}
+
+// ModifyLabels maps label add/remove to Outlook's categories field via a
+// PATCH to /me/messages/{id}.
+func (c *Client) ModifyLabels(ctx context.Context, id string, add, remove []string) error {
+    if err := c.rateLimiter.Wait(ctx); err != nil {
+        return fmt.Errorf("rate limit exceeded: %w", err)
+    }
+
+    current, err := c.GetEmail(ctx, id)
+    if err != nil {
+        return fmt.Errorf("failed to load current categories: %w", err)
+    }
+
+    categories := mergeCategories(current.Labels, add, remove)
+
+    operation := func() error {
+        return c.patchJSON(ctx, fmt.Sprintf("/me/messages/%s", id), map[string]interface{}{"categories": categories})
+    }
+    if err := c.executeWithRetry(ctx, "modify_labels", operation); err != nil {
+        c.metrics.errorCounter.WithLabelValues("modify_labels").Inc()
+        return fmt.Errorf("failed to modify categories: %w", err)
+    }
+
+    c.metrics.requestCounter.WithLabelValues("modify_labels").Inc()
+    return nil
+}
+
+func mergeCategories(current, add, remove []string) []string {
+    removeSet := make(map[string]bool, len(remove))
+    for _, r := range remove {
+        removeSet[r] = true
+    }
+
+    result := make([]string, 0, len(current)+len(add))
+    seen := make(map[string]bool, len(current)+len(add))
+    for _, c := range current {
+        if removeSet[c] || seen[c] {
+            continue
+        }
+        result = append(result, c)
+        seen[c] = true
+    }
+    for _, a := range add {
+        if seen[a] {
+            continue
+        }
+        result = append(result, a)
+        seen[a] = true
+    }
+    return result
+}
+
+// GetAttachment streams a single attachment's decoded bytes via
+// GET /me/messages/{id}/attachments/{attachmentId}.
+func (c *Client) GetAttachment(ctx context.Context, messageID, attachmentID string) (io.ReadCloser, error) {
+    if err := c.rateLimiter.Wait(ctx); err != nil {
+        return nil, fmt.Errorf("rate limit exceeded: %w", err)
+    }
+
+    var att struct {
+        ContentBytes string `json:"contentBytes"`
+    }
+    if err := c.getJSON(ctx, fmt.Sprintf("/me/messages/%s/attachments/%s", messageID, attachmentID), &att); err != nil {
+        return nil, fmt.Errorf("failed to get attachment: %w", err)
+    }
+
+    data, err := base64.StdEncoding.DecodeString(att.ContentBytes)
+    if err != nil {
+        return nil, fmt.Errorf("failed to decode attachment data: %w", err)
+    }
+
+    return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func toGraphMessage(msg *models.OutgoingEmail) graphOutgoingMessage {
+    gm := graphOutgoingMessage{
+        Subject: msg.Subject,
+        Body:    graphItemBody{ContentType: "Text", Content: msg.TextBody},
+    }
+    if msg.HTMLBody != "" {
+        gm.Body = graphItemBody{ContentType: "HTML", Content: msg.HTMLBody}
+    }
+    for _, to := range msg.ToAddresses {
+        gm.ToRecipients = append(gm.ToRecipients, graphRecipient{EmailAddress: graphEmailAddress{Address: to}})
+    }
+    for _, cc := range msg.CCAddresses {
+        gm.CcRecipients = append(gm.CcRecipients, graphRecipient{EmailAddress: graphEmailAddress{Address: cc}})
+    }
+    return gm
+}
+
 // executeGetEmails performs the actual API call to retrieve emails
 func (c *Client) executeGetEmails(ctx context.Context, folderID string, pageSize int, pageToken string, emails *[]*models.Email, nextPageToken *string) error {
     if pageSize <= 0 || pageSize > 1000 {
@@ -175,12 +455,15 @@ func (c *Client) executeGetEmails(ctx context.Context, folderID string, pageSize
     return nil
 }
 
-// executeWithRetry implements retry logic with exponential backoff
-func (c *Client) executeWithRetry(ctx context.Context, operation func() error) error {
+// executeWithRetry implements retry logic with exponential backoff, routing
+// each attempt through op's breaker so repeated failures on one operation
+// (e.g. get_emails) don't starve unrelated ones sharing this client.
+func (c *Client) executeWithRetry(ctx context.Context, op string, operation func() error) error {
+    key := breaker.Key{Provider: breakerProvider, Operation: op}
     var err error
     for attempt := 0; attempt < maxRetries; attempt++ {
-        err = c.circuitBreaker.Execute(func() error {
-            return operation()
+        _, err = c.breakers.Execute(key, func() (interface{}, error) {
+            return nil, operation()
         })
         if err == nil {
             return nil
@@ -280,4 +563,327 @@ func isRetryableError(err error) bool {
 func extractNextPageToken(nextLink string) string {
     // Implementation to extract page token from next link
     return nextLink
+}
+
+// SyncChanges retrieves mailbox changes since cursor using the Graph delta
+// query. On the first call (empty cursor) it starts a fresh delta session
+// against the inbox; on subsequent calls cursor is the opaque
+// @odata.deltaLink returned by the previous call.
+func (c *Client) SyncChanges(ctx context.Context, cursor string) ([]*models.EmailChange, string, error) {
+    if err := c.rateLimiter.Wait(ctx); err != nil {
+        return nil, "", fmt.Errorf("rate limit exceeded: %w", err)
+    }
+
+    timer := prometheus.NewTimer(c.metrics.requestLatency.WithLabelValues("sync_changes"))
+    defer timer.ObserveDuration()
+
+    if cursor == "" {
+        c.metrics.requestCounter.WithLabelValues("sync_full_resync").Inc()
+    }
+
+    var changes []*models.EmailChange
+    var nextLink string
+
+    operation := func() error {
+        select {
+        case <-ctx.Done():
+            return ctx.Err()
+        default:
+            return c.executeSyncChanges(ctx, cursor, &changes, &nextLink)
+        }
+    }
+
+    if err := c.executeWithRetry(ctx, "sync_changes", operation); err != nil {
+        c.metrics.errorCounter.WithLabelValues("sync_changes").Inc()
+        return nil, "", fmt.Errorf("failed to sync changes: %w", err)
+    }
+
+    c.metrics.requestCounter.WithLabelValues("sync_changes").Inc()
+    return changes, nextLink, nil
+}
+
+// executeSyncChanges performs the delta query against the Graph messages
+// endpoint, following @odata.nextLink the same way incrementalSync follows
+// NextPageToken so a single call reflects every change since cursor rather
+// than just the first page, translating @removed annotations into Deleted
+// changes and every other message payload into a Modified change. No
+// Added change is emitted: a new message is reported as Modified.
+func (c *Client) executeSyncChanges(ctx context.Context, cursor string, changes *[]*models.EmailChange, nextLink *string) error {
+    deltaURL := cursor
+    if deltaURL == "" {
+        deltaURL = fmt.Sprintf("%s/me/mailFolders/inbox/messages/delta", graphBaseURL)
+    }
+
+    for {
+        response, err := c.graphClient.Users().ID("me").MailFolders().ID("inbox").Messages().Delta().RequestWithURL(deltaURL).Get(ctx)
+        if err != nil {
+            return fmt.Errorf("graph delta request failed: %w", err)
+        }
+
+        for _, msg := range response.GetValue() {
+            if msg.GetRemoved() != nil {
+                *changes = append(*changes, &models.EmailChange{
+                    MessageID: msg.GetId(),
+                    Change:    models.ChangeDeleted,
+                })
+                continue
+            }
+
+            email := convertToEmail(msg)
+            *changes = append(*changes, &models.EmailChange{
+                MessageID: msg.GetId(),
+                Change:    models.ChangeModified,
+                Email:     email,
+            })
+        }
+
+        if deltaLink := response.GetOdataDeltaLink(); deltaLink != "" {
+            *nextLink = deltaLink
+            return nil
+        }
+
+        next := response.GetOdataNextLink()
+        if next == "" {
+            return nil
+        }
+        deltaURL = next
+    }
+}
+
+// graphBatchRequestItem is a single sub-request within a Graph $batch envelope.
+type graphBatchRequestItem struct {
+    ID     string `json:"id"`
+    Method string `json:"method"`
+    URL    string `json:"url"`
+}
+
+type graphBatchRequest struct {
+    Requests []graphBatchRequestItem `json:"requests"`
+}
+
+type graphBatchResponseItem struct {
+    ID     string          `json:"id"`
+    Status int             `json:"status"`
+    Body   json.RawMessage `json:"body"`
+}
+
+type graphBatchResponse struct {
+    Responses []graphBatchResponseItem `json:"responses"`
+}
+
+// GetEmailsBatch retrieves multiple messages via Graph's JSON $batch
+// endpoint, automatically chunking when ids exceeds maxBatchRequestSize.
+// Per-item failures are collected into a *models.BatchError rather than
+// failing the whole call.
+func (c *Client) GetEmailsBatch(ctx context.Context, ids []string) ([]*models.Email, error) {
+    timer := prometheus.NewTimer(c.metrics.requestLatency.WithLabelValues("get_emails_batch"))
+    defer timer.ObserveDuration()
+
+    results := make([]*models.Email, len(ids))
+    batchErr := &models.BatchError{Errors: make(map[int]error)}
+
+    for offset := 0; offset < len(ids); offset += maxBatchRequestSize {
+        end := offset + maxBatchRequestSize
+        if end > len(ids) {
+            end = len(ids)
+        }
+        chunk := ids[offset:end]
+
+        // One token per sub-request, taken one at a time: WaitN fails
+        // outright when n exceeds the limiter's burst of rateBurst (10).
+        for range chunk {
+            if err := c.rateLimiter.Wait(ctx); err != nil {
+                return nil, fmt.Errorf("rate limit exceeded: %w", err)
+            }
+        }
+
+        var chunkResp graphBatchResponse
+        operation := func() error {
+            return c.postJSON(ctx, "/$batch", buildBatchRequest(chunk), &chunkResp)
+        }
+        if err := c.executeWithRetry(ctx, "get_emails_batch", operation); err != nil {
+            c.metrics.errorCounter.WithLabelValues("get_emails_batch").Inc()
+            return nil, fmt.Errorf("batch request failed: %w", err)
+        }
+
+        for _, item := range chunkResp.Responses {
+            idx, err := strconv.Atoi(item.ID)
+            if err != nil || idx < 0 || idx >= len(chunk) {
+                continue
+            }
+            globalIdx := offset + idx
+
+            if item.Status >= 300 {
+                batchErr.Errors[globalIdx] = fmt.Errorf("sub-request %d failed with status %d", globalIdx, item.Status)
+                c.metrics.errorCounter.WithLabelValues("get_emails_batch_item").Inc()
+                continue
+            }
+
+            var msg msgraph.Message
+            if err := json.Unmarshal(item.Body, &msg); err != nil {
+                batchErr.Errors[globalIdx] = fmt.Errorf("failed to decode sub-response %d: %w", globalIdx, err)
+                continue
+            }
+            results[globalIdx] = convertToEmail(&msg)
+        }
+    }
+
+    c.metrics.requestCounter.WithLabelValues("get_emails_batch").Inc()
+    if len(batchErr.Errors) > 0 {
+        return results, batchErr
+    }
+    return results, nil
+}
+
+func buildBatchRequest(ids []string) graphBatchRequest {
+    req := graphBatchRequest{Requests: make([]graphBatchRequestItem, len(ids))}
+    for i, id := range ids {
+        req.Requests[i] = graphBatchRequestItem{
+            ID:     strconv.Itoa(i),
+            Method: http.MethodGet,
+            URL:    fmt.Sprintf("/me/messages/%s", id),
+        }
+    }
+    return req
+}
+
+// graphSubscriptionRequest is the JSON body posted to /subscriptions.
+type graphSubscriptionRequest struct {
+    ChangeType         string `json:"changeType"`
+    NotificationURL    string `json:"notificationUrl"`
+    Resource           string `json:"resource"`
+    ExpirationDateTime string `json:"expirationDateTime"`
+    ClientState        string `json:"clientState"`
+}
+
+type graphSubscriptionResponse struct {
+    ID                 string `json:"id"`
+    ExpirationDateTime string `json:"expirationDateTime"`
+    ClientState        string `json:"clientState"`
+}
+
+// maxSubscriptionLifetime is the Graph-enforced ceiling for a message
+// subscription's expiration.
+const maxSubscriptionLifetime = 71 * time.Hour // just under Graph's 3-day cap
+
+// Watch creates a Microsoft Graph change notification subscription on
+// /me/messages so that created/updated/deleted events are delivered to
+// opts.NotificationURL instead of requiring polling.
+func (c *Client) Watch(ctx context.Context, opts pushsync.WatchOptions) (*pushsync.Subscription, error) {
+    if err := c.rateLimiter.Wait(ctx); err != nil {
+        return nil, fmt.Errorf("rate limit exceeded: %w", err)
+    }
+
+    timer := prometheus.NewTimer(c.metrics.requestLatency.WithLabelValues("watch"))
+    defer timer.ObserveDuration()
+
+    expiry := opts.Expiry
+    if expiry <= 0 || expiry > maxSubscriptionLifetime {
+        expiry = maxSubscriptionLifetime
+    }
+    expiresAt := time.Now().Add(expiry)
+
+    reqBody := graphSubscriptionRequest{
+        ChangeType:         "created,updated,deleted",
+        NotificationURL:    opts.NotificationURL,
+        Resource:           "/me/messages",
+        ExpirationDateTime: expiresAt.UTC().Format(time.RFC3339),
+        ClientState:        opts.ClientState,
+    }
+
+    var resp graphSubscriptionResponse
+    if err := c.postJSON(ctx, "/subscriptions", reqBody, &resp); err != nil {
+        c.metrics.errorCounter.WithLabelValues("watch").Inc()
+        return nil, fmt.Errorf("failed to create graph subscription: %w", err)
+    }
+
+    c.metrics.requestCounter.WithLabelValues("watch").Inc()
+
+    sub := &pushsync.Subscription{
+        ID:          resp.ID,
+        Provider:    pushsync.ProviderOutlook,
+        ExpiresAt:   expiresAt,
+        ClientState: resp.ClientState,
+        ResourceID:  resp.ID,
+    }
+    return sub, nil
+}
+
+// Unwatch deletes an active Graph subscription.
+func (c *Client) Unwatch(ctx context.Context, sub *pushsync.Subscription) error {
+    if err := c.rateLimiter.Wait(ctx); err != nil {
+        return fmt.Errorf("rate limit exceeded: %w", err)
+    }
+
+    url := fmt.Sprintf("%s/subscriptions/%s", graphBaseURL, sub.ResourceID)
+    req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+    if err != nil {
+        return fmt.Errorf("failed to build unwatch request: %w", err)
+    }
+
+    resp, err := c.httpClient.Do(req)
+    if err != nil {
+        c.metrics.errorCounter.WithLabelValues("unwatch").Inc()
+        return fmt.Errorf("failed to delete graph subscription: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode >= http.StatusBadRequest {
+        c.metrics.errorCounter.WithLabelValues("unwatch").Inc()
+        return fmt.Errorf("graph subscription delete failed: status %d", resp.StatusCode)
+    }
+
+    c.metrics.requestCounter.WithLabelValues("unwatch").Inc()
+    return nil
+}
+
+// postJSON issues an authenticated POST to a Graph endpoint and, if out is
+// non-nil, decodes the JSON response into it.
+func (c *Client) postJSON(ctx context.Context, path string, body interface{}, out interface{}) error {
+    return c.requestJSON(ctx, http.MethodPost, path, body, out)
+}
+
+// patchJSON issues an authenticated PATCH to a Graph endpoint.
+func (c *Client) patchJSON(ctx context.Context, path string, body interface{}) error {
+    return c.requestJSON(ctx, http.MethodPatch, path, body, nil)
+}
+
+// getJSON issues an authenticated GET to a Graph endpoint and decodes the
+// JSON response into out.
+func (c *Client) getJSON(ctx context.Context, path string, out interface{}) error {
+    return c.requestJSON(ctx, http.MethodGet, path, nil, out)
+}
+
+func (c *Client) requestJSON(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+    var reader io.Reader
+    if body != nil {
+        payload, err := json.Marshal(body)
+        if err != nil {
+            return fmt.Errorf("failed to marshal request body: %w", err)
+        }
+        reader = bytes.NewReader(payload)
+    }
+
+    req, err := http.NewRequestWithContext(ctx, method, graphBaseURL+path, reader)
+    if err != nil {
+        return fmt.Errorf("failed to build request: %w", err)
+    }
+    if body != nil {
+        req.Header.Set("Content-Type", "application/json")
+    }
+
+    resp, err := c.httpClient.Do(req)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode >= http.StatusBadRequest {
+        return fmt.Errorf("graph request to %s failed: status %d", path, resp.StatusCode)
+    }
+
+    if out == nil {
+        return nil
+    }
+    return json.NewDecoder(resp.Body).Decode(out)
 }
\ No newline at end of file
@@ -0,0 +1,38 @@
+// Package provider defines a unified surface over the Gmail and Outlook
+// clients so callers can drive either mailbox provider uniformly, enabling
+// provider-agnostic workers, tests, and mocks.
+package provider
+
+import (
+	"context"
+	"io"
+
+	"github.com/email-management-platform/backend/email-service/internal/models"
+)
+
+// ListOptions controls pagination when listing a provider's messages.
+type ListOptions struct {
+	FolderID  string
+	PageSize  int
+	PageToken string
+}
+
+// Page is a single page of listed emails together with the token to fetch
+// the next one.
+type Page struct {
+	Emails        []*models.Email
+	NextPageToken string
+}
+
+// EmailProvider is implemented by gmail.GmailClient and outlook.Client,
+// giving callers a single, provider-agnostic surface for the operations
+// shared across both mailbox backends.
+type EmailProvider interface {
+	GetEmail(ctx context.Context, id string) (*models.Email, error)
+	ListEmails(ctx context.Context, opts ListOptions) (*Page, error)
+	SendEmail(ctx context.Context, msg *models.OutgoingEmail) (string, error)
+	ReplyEmail(ctx context.Context, threadID string, msg *models.OutgoingEmail) (string, error)
+	ModifyLabels(ctx context.Context, id string, add, remove []string) error
+	GetAttachment(ctx context.Context, messageID, attachmentID string) (io.ReadCloser, error)
+	SyncChanges(ctx context.Context, cursor string) ([]*models.EmailChange, string, error)
+}
@@ -0,0 +1,169 @@
+// Package pushsync provides shared types and an HTTP dispatch handler for
+// server-initiated mailbox change notifications (Gmail Pub/Sub push and
+// Microsoft Graph webhook subscriptions), layered over the polling-based
+// gmail and outlook clients so callers don't have to poll SyncChanges.
+package pushsync
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Provider identifies which upstream issued a Subscription/ChangeNotification.
+type Provider string
+
+// Supported providers.
+const (
+	ProviderGmail   Provider = "gmail"
+	ProviderOutlook Provider = "outlook"
+)
+
+// WatchOptions configures a provider's push subscription.
+type WatchOptions struct {
+	// Topic is the Pub/Sub topic name (Gmail) to publish notifications to.
+	Topic string
+	// LabelFilter restricts Gmail notifications to the given label IDs.
+	LabelFilter []string
+	// NotificationURL is the webhook callback (Outlook) that Graph will POST to.
+	NotificationURL string
+	// ClientState is an opaque value echoed back on every Outlook notification
+	// so the handler can reject forged callbacks.
+	ClientState string
+	// Expiry bounds how long the subscription should live before renewal;
+	// providers clamp this to their own maximums (Outlook: ~3 days).
+	Expiry time.Duration
+}
+
+// Subscription represents an active push subscription with a provider.
+type Subscription struct {
+	ID           string
+	Provider     Provider
+	ExpiresAt    time.Time
+	HistoryID    string // Gmail: historyId at the time the watch was created
+	ClientState  string // Outlook: echoed on every notification for verification
+	ResourceID   string // Outlook: the subscription ID returned by Graph
+}
+
+// Expired reports whether the subscription has passed its expiry.
+func (s *Subscription) Expired() bool {
+	return !s.ExpiresAt.IsZero() && time.Now().After(s.ExpiresAt)
+}
+
+// ChangeNotification is the normalized event handed to the deliver callback
+// once an inbound push payload has been validated and decoded.
+type ChangeNotification struct {
+	Provider  Provider
+	AccountID string
+	// HistoryID is populated for Gmail notifications and is the cursor that
+	// should be passed to GmailClient.SyncChanges to resolve actual changes.
+	HistoryID string
+	// MessageID is populated for Outlook notifications, which carry the
+	// changed resource directly.
+	MessageID string
+	ChangeType string
+}
+
+// gmailPushPayload mirrors the base64-JSON body Gmail publishes to Pub/Sub.
+type gmailPushPayload struct {
+	EmailAddress string `json:"emailAddress"`
+	HistoryID    uint64 `json:"historyId"`
+}
+
+// graphNotification mirrors a single entry in a Graph change notification body.
+type graphNotification struct {
+	SubscriptionID                string `json:"subscriptionId"`
+	ClientState                   string `json:"clientState"`
+	ChangeType                    string `json:"changeType"`
+	Resource                      string `json:"resource"`
+	ResourceData                  struct {
+		ID string `json:"id"`
+	} `json:"resourceData"`
+}
+
+type graphNotificationBody struct {
+	Value []graphNotification `json:"value"`
+}
+
+// Handler returns an http.HandlerFunc that validates and dispatches inbound
+// push callbacks from both providers, invoking deliver for each decoded
+// ChangeNotification. knownClientStates maps a Graph subscription ID to the
+// clientState that was supplied when the subscription was created, so
+// notifications with a mismatched or missing clientState are rejected.
+func Handler(deliver func(ChangeNotification), knownClientStates map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Microsoft Graph subscription validation handshake: echo the token
+		// back as text/plain so Graph considers the endpoint verified.
+		if token := r.URL.Query().Get("validationToken"); token != "" {
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(token))
+			return
+		}
+
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Read the body once: both providers' formats are tried against the
+		// same bytes below, since decoding it with an http.Request's Reader
+		// twice would leave the second attempt reading from an already
+		// drained body.
+		raw, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var body graphNotificationBody
+		if err := json.Unmarshal(raw, &body); err == nil && len(body.Value) > 0 {
+			handleGraphNotifications(body.Value, knownClientStates, deliver)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		// Fall back to the Gmail Pub/Sub push envelope.
+		var envelope struct {
+			Message struct {
+				Data []byte `json:"data"`
+			} `json:"message"`
+		}
+		if err := json.Unmarshal(raw, &envelope); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var payload gmailPushPayload
+		if err := json.Unmarshal(envelope.Message.Data, &payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		deliver(ChangeNotification{
+			Provider:  ProviderGmail,
+			AccountID: payload.EmailAddress,
+			HistoryID: formatHistoryID(payload.HistoryID),
+		})
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func handleGraphNotifications(notifications []graphNotification, knownClientStates map[string]string, deliver func(ChangeNotification)) {
+	for _, n := range notifications {
+		if expected, ok := knownClientStates[n.SubscriptionID]; !ok || expected != n.ClientState {
+			continue
+		}
+		deliver(ChangeNotification{
+			Provider:   ProviderOutlook,
+			MessageID:  n.ResourceData.ID,
+			ChangeType: n.ChangeType,
+		})
+	}
+}
+
+func formatHistoryID(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
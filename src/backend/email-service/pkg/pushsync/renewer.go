@@ -0,0 +1,86 @@
+package pushsync
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus" // v1.17.0
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics collectors
+var (
+	renewalTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pushsync_renewal_total",
+		Help: "Total number of push subscription renewal attempts",
+	}, []string{"provider", "status"})
+)
+
+// Watcher is implemented by both gmail.GmailClient and outlook.Client.
+type Watcher interface {
+	Watch(ctx context.Context, opts WatchOptions) (*Subscription, error)
+	Unwatch(ctx context.Context, sub *Subscription) error
+}
+
+// Renewer periodically re-issues a provider's push subscription before it
+// expires: Graph subscriptions must be renewed before expirationDateTime
+// (max ~3 days out), and Gmail watches must be re-created roughly daily.
+type Renewer struct {
+	watcher  Watcher
+	opts     WatchOptions
+	interval time.Duration
+	current  *Subscription
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewRenewer creates a Renewer that keeps sub alive by re-calling Watch
+// every interval.
+func NewRenewer(watcher Watcher, opts WatchOptions, sub *Subscription, interval time.Duration) *Renewer {
+	return &Renewer{
+		watcher:  watcher,
+		opts:     opts,
+		interval: interval,
+		current:  sub,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start runs the renewal loop until the context is cancelled or Stop is
+// called. It does not unwatch the underlying subscription on exit: that is
+// the caller's responsibility when the process is being decommissioned
+// rather than rolling-restarted.
+func (r *Renewer) Start(ctx context.Context) {
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				sub, err := r.watcher.Watch(ctx, r.opts)
+				if err != nil {
+					renewalTotal.WithLabelValues(string(r.current.Provider), "failure").Inc()
+					log.Printf("pushsync: renewal failed for provider %s: %v", r.current.Provider, err)
+					continue
+				}
+				renewalTotal.WithLabelValues(string(sub.Provider), "success").Inc()
+				r.current = sub
+			}
+		}
+	}()
+}
+
+// Stop halts the renewal loop and blocks until the goroutine has exited.
+func (r *Renewer) Stop() {
+	close(r.stop)
+	<-r.done
+}
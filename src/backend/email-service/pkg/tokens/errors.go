@@ -0,0 +1,7 @@
+package tokens
+
+import "errors"
+
+// ErrNotFound is returned by Store.Get when no token has been persisted for
+// the requested user ID.
+var ErrNotFound = errors.New("tokens: no token found for user")
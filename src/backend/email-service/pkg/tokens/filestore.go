@@ -0,0 +1,76 @@
+package tokens
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/oauth2" // v0.13.0
+)
+
+// FileStore persists tokens as one JSON file per user under Dir, mirroring
+// the tokenCacheFile pattern used by simple OAuth2 CLI examples.
+type FileStore struct {
+	Dir string
+	mu  sync.Mutex
+}
+
+// NewFileStore creates a FileStore rooted at dir, creating it if necessary.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create token store directory: %w", err)
+	}
+	return &FileStore{Dir: dir}, nil
+}
+
+func (s *FileStore) path(userID string) string {
+	return filepath.Join(s.Dir, fmt.Sprintf("%s.json", filepath.Base(userID)))
+}
+
+// Get implements Store.
+func (s *FileStore) Get(userID string) (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(userID))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	var token oauth2.Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token: %w", err)
+	}
+	return &token, nil
+}
+
+// Put implements Store.
+func (s *FileStore) Put(userID string, token *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to marshal token: %w", err)
+	}
+	if err := os.WriteFile(s.path(userID), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+	return nil
+}
+
+// Delete implements Store.
+func (s *FileStore) Delete(userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(userID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete token file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package tokens
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8" // v8.11.5
+	"golang.org/x/oauth2"          // v0.13.0
+)
+
+// RedisStore persists tokens as JSON values in Redis, suitable for
+// multi-instance deployments that can't rely on local disk.
+type RedisStore struct {
+	client    *redis.Client
+	keyPrefix string
+	ttl       time.Duration
+}
+
+// NewRedisStore creates a RedisStore using client, namespacing keys with
+// keyPrefix. A ttl of zero means tokens never expire from Redis on their
+// own (refresh still happens through the normal OAuth2 flow).
+func NewRedisStore(client *redis.Client, keyPrefix string, ttl time.Duration) *RedisStore {
+	return &RedisStore{client: client, keyPrefix: keyPrefix, ttl: ttl}
+}
+
+func (s *RedisStore) key(userID string) string {
+	return fmt.Sprintf("%s:%s", s.keyPrefix, userID)
+}
+
+// Get implements Store.
+func (s *RedisStore) Get(userID string) (*oauth2.Token, error) {
+	ctx := context.Background()
+	data, err := s.client.Get(ctx, s.key(userID)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token from redis: %w", err)
+	}
+
+	var token oauth2.Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token: %w", err)
+	}
+	return &token, nil
+}
+
+// Put implements Store.
+func (s *RedisStore) Put(userID string, token *oauth2.Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to marshal token: %w", err)
+	}
+
+	ctx := context.Background()
+	if err := s.client.Set(ctx, s.key(userID), data, s.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to write token to redis: %w", err)
+	}
+	return nil
+}
+
+// Delete implements Store.
+func (s *RedisStore) Delete(userID string) error {
+	ctx := context.Background()
+	if err := s.client.Del(ctx, s.key(userID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete token from redis: %w", err)
+	}
+	return nil
+}
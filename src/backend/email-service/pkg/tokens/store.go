@@ -0,0 +1,20 @@
+// Package tokens provides pluggable persistence for per-user OAuth2 tokens
+// so the Gmail and Outlook clients can survive restarts without forcing
+// every user back through the three-legged authorization flow.
+package tokens
+
+import (
+	"golang.org/x/oauth2" // v0.13.0
+)
+
+// Store persists OAuth2 tokens keyed by an application-defined user ID
+// (typically the mailbox address).
+type Store interface {
+	// Get returns the stored token for userID, or an error satisfying
+	// errors.Is(err, ErrNotFound) if none exists.
+	Get(userID string) (*oauth2.Token, error)
+	// Put persists token for userID, overwriting any existing entry.
+	Put(userID string, token *oauth2.Token) error
+	// Delete removes any stored token for userID.
+	Delete(userID string) error
+}
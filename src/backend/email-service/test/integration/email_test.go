@@ -3,11 +3,17 @@
 package integration
 
 import (
+    "archive/zip"
+    "bufio"
+    "bytes"
     "context"
     "database/sql"
     "flag"
     "fmt"
+    "net"
     "os"
+    "strconv"
+    "strings"
     "sync"
     "testing"
     "time"
@@ -19,9 +25,14 @@ import (
     "github.com/stretchr/testify/require" // v1.8.4
     "github.com/stretchr/testify/suite" // v1.8.4
 
+    "github.com/email-management-platform/backend/email-service/internal/config"
+    "github.com/email-management-platform/backend/email-service/internal/export"
     "github.com/email-management-platform/backend/email-service/internal/handlers"
+    "github.com/email-management-platform/backend/email-service/internal/incoming"
     "github.com/email-management-platform/backend/email-service/internal/models"
     "github.com/email-management-platform/backend/email-service/internal/services"
+    "github.com/email-management-platform/backend/email-service/internal/smtp"
+    "github.com/email-management-platform/backend/email-service/internal/templates"
 )
 
 const (
@@ -30,6 +41,40 @@ const (
     maxTestEmails = 100
 )
 
+// SMTP config for outbound-mail integration tests, overridable via
+// SMTP_HOST/SMTP_PORT for runs against a real server instead of the
+// in-process mock. TestSMTPSenderDeliversRenderedTemplate spins up its own
+// mock server rather than relying on these flags directly, so a real SMTP
+// server at this address is only needed if that test is changed to target
+// one.
+var (
+    smtpHost = flag.String("smtp-host", envOrDefault("SMTP_HOST", "localhost"), "host of the SMTP server used by outbound-mail integration tests")
+    smtpPort = flag.Int("smtp-port", envIntOrDefault("SMTP_PORT", 2525), "port of the SMTP server used by outbound-mail integration tests")
+)
+
+// envOrDefault returns the value of environment variable key, or fallback
+// if it's unset.
+func envOrDefault(key, fallback string) string {
+    if v := os.Getenv(key); v != "" {
+        return v
+    }
+    return fallback
+}
+
+// envIntOrDefault returns the integer value of environment variable key,
+// or fallback if it's unset or not a valid integer.
+func envIntOrDefault(key string, fallback int) int {
+    v := os.Getenv(key)
+    if v == "" {
+        return fallback
+    }
+    parsed, err := strconv.Atoi(v)
+    if err != nil {
+        return fallback
+    }
+    return parsed
+}
+
 // Metrics collectors for test monitoring
 var (
     testDuration = prometheus.NewHistogramVec(
@@ -106,7 +151,16 @@ func (s *EmailTestSuite) SetupSuite() {
     s.service, err = services.NewEmailService(s.db)
     require.NoError(s.T(), err, "Failed to create email service")
 
-    s.handler, err = handlers.NewEmailHandler(s.service)
+    s.handler, err = handlers.NewEmailHandler(s.service, config.RateLimitConfig{
+        ReadRatePerSecond:   config.DefaultReadRatePerSecond,
+        ReadBurst:           config.DefaultReadBurst,
+        SendRatePerSecond:   config.DefaultSendRatePerSecond,
+        SendBurst:           config.DefaultSendBurst,
+        GlobalRatePerSecond: config.DefaultGlobalRatePerSecond,
+        GlobalBurst:         config.DefaultGlobalBurst,
+        VisitorTTL:          config.DefaultVisitorTTL,
+        CleanupInterval:     config.DefaultVisitorCleanupInterval,
+    }, nil)
     require.NoError(s.T(), err, "Failed to create email handler")
 
     // Set up HTTP router
@@ -262,6 +316,474 @@ func (s *EmailTestSuite) TestErrorScenarios() {
     testErrors.WithLabelValues("error_scenarios", "timeout_error").Inc()
 }
 
+// batchProcessorFairnessWorkload submits emails for numAccounts accounts,
+// emailsPerAccount each, through a BatchProcessor with the given number
+// of workers, and returns (a) the wallclock duration of the whole run and
+// (b) the spread between the first and last account to have all of its
+// emails observable via GetEmailByID (the fairness signal: how far behind
+// the slowest account fell relative to the fastest).
+func (s *EmailTestSuite) batchProcessorFairnessWorkload(numAccounts, emailsPerAccount, workers int) (time.Duration, time.Duration) {
+    processor, err := services.NewBatchProcessor(s.service, nil, services.BatchProcessorOptions{
+        Workers:   workers,
+        QueueSize: numAccounts * emailsPerAccount,
+    })
+    require.NoError(s.T(), err, "Failed to create batch processor")
+    processor.Start()
+    defer processor.Stop()
+
+    type accountDone struct {
+        accountID string
+        at        time.Time
+    }
+    doneCh := make(chan accountDone, numAccounts)
+
+    start := time.Now()
+    var wg sync.WaitGroup
+    for a := 0; a < numAccounts; a++ {
+        accountID := fmt.Sprintf("fairness-account-%d", a)
+        wg.Add(1)
+        go func(accountID string) {
+            defer wg.Done()
+
+            messageIDs := make([]string, emailsPerAccount)
+            for i := 0; i < emailsPerAccount; i++ {
+                email := &models.Email{
+                    MessageID:   uuid.New().String(),
+                    AccountID:   accountID,
+                    Subject:     fmt.Sprintf("Fairness Test Email %d", i),
+                    Content:     "fairness test content",
+                    FromAddress: "sender@test.com",
+                    ToAddresses: []string{"recipient@test.com"},
+                    Status:      models.StatusUnread,
+                    SentAt:      time.Now(),
+                }
+                messageIDs[i] = email.MessageID
+                require.NoError(s.T(), processor.Submit(email), "Failed to submit email for account %s", accountID)
+            }
+
+            require.Eventually(s.T(), func() bool {
+                for _, id := range messageIDs {
+                    stored, err := s.service.GetEmailByID(s.ctx, id, accountID)
+                    if err != nil || stored == nil {
+                        return false
+                    }
+                }
+                return true
+            }, time.Second*30, time.Millisecond*20, "Expected all emails for account %s to be processed", accountID)
+
+            doneCh <- accountDone{accountID: accountID, at: time.Now()}
+        }(accountID)
+    }
+
+    wg.Wait()
+    close(doneCh)
+    elapsed := time.Since(start)
+
+    var first, last time.Time
+    for d := range doneCh {
+        if first.IsZero() || d.at.Before(first) {
+            first = d.at
+        }
+        if d.at.After(last) {
+            last = d.at
+        }
+    }
+
+    return elapsed, last.Sub(first)
+}
+
+// TestBatchProcessorFairness submits thousands of emails across hundreds
+// of accounts through a BatchProcessor and verifies (a) the last account
+// to finish does so no more than maxFairnessSpread after the first, and
+// (b) a pool of 16 workers takes less wallclock than a pool of 4, rather
+// than the same or more (which would indicate the per-account
+// sharding was accidentally serializing everything onto one worker).
+func (s *EmailTestSuite) TestBatchProcessorFairness() {
+    timer := prometheus.NewTimer(testDuration.WithLabelValues("batch_processor_fairness"))
+    defer timer.ObserveDuration()
+
+    const (
+        numAccounts       = 200
+        emailsPerAccount  = 10
+        maxFairnessSpread = time.Second * 10
+    )
+
+    smallPoolElapsed, spread := s.batchProcessorFairnessWorkload(numAccounts, emailsPerAccount, 4)
+    assert.LessOrEqual(s.T(), spread, maxFairnessSpread,
+        "Expected every account to finish within %s of the slowest account, got a %s spread", maxFairnessSpread, spread)
+
+    largePoolElapsed, _ := s.batchProcessorFairnessWorkload(numAccounts, emailsPerAccount, 16)
+    s.T().Logf("batch processor wallclock: %s with 4 workers, %s with 16 workers", smallPoolElapsed, largePoolElapsed)
+    assert.Less(s.T(), largePoolElapsed, smallPoolElapsed,
+        "Expected a larger worker pool to reduce wallclock, not leave it unchanged or worse")
+}
+
+// TestIncomingMailThreading feeds a canned multipart RFC 822 message
+// through a mock IMAP server and verifies incoming.Fetcher parses its
+// attachment and threads it against its In-Reply-To header.
+func (s *EmailTestSuite) TestIncomingMailThreading() {
+    timer := prometheus.NewTimer(testDuration.WithLabelValues("incoming_mail_threading"))
+    defer timer.ObserveDuration()
+
+    mailbox := &mockIMAPServer{
+        messages: map[uint32][]byte{1: []byte(cannedReplyMessage)},
+    }
+    processor := &capturingProcessor{}
+    resolver := &staticThreadResolver{
+        matchRef:       "parent-message-id@example.com",
+        threadID:       "thread-123",
+        conversationID: "conversation-123",
+        nextPosition:   2,
+    }
+
+    fetcher, err := incoming.NewFetcher(incoming.Config{
+        Mailbox:      "INBOX",
+        PollInterval: time.Hour,
+        AccountID:    "test-account",
+    }, mailbox, processor, resolver, nil)
+    require.NoError(s.T(), err, "Failed to create incoming fetcher")
+
+    pollCtx, cancel := context.WithTimeout(s.ctx, time.Second*5)
+    defer cancel()
+
+    runErr := make(chan error, 1)
+    go func() { runErr <- fetcher.Run(pollCtx) }()
+
+    require.Eventually(s.T(), func() bool {
+        return len(processor.received()) == 1
+    }, time.Second*5, time.Millisecond*10, "Expected the fetcher to dispatch the canned message")
+
+    cancel()
+    <-runErr
+
+    email := processor.received()[0]
+    assert.Equal(s.T(), "test-account", email.AccountID, "Email should be stamped with the fetcher's account")
+    assert.Equal(s.T(), "Re: Test Thread Parent", email.Subject)
+    assert.Contains(s.T(), email.Content, "Reply body content", "Expected the text/plain part to become Content")
+    require.Len(s.T(), email.Attachments, 1, "Expected the inline attachment to be parsed")
+    assert.Equal(s.T(), "note.txt", email.Attachments[0].Filename)
+    assert.NotEmpty(s.T(), email.Attachments[0].Checksum, "Expected a SHA-256 checksum on the parsed attachment")
+    assert.Equal(s.T(), "thread-123", email.ThreadID, "Expected the message to thread against its In-Reply-To header")
+    assert.Equal(s.T(), "conversation-123", email.ConversationID)
+    assert.Equal(s.T(), int32(2), email.ThreadPosition)
+}
+
+// TestComplianceExportRoundTrip verifies a two-message thread survives a
+// round trip through each of export.EMLExporter, export.CSVExporter, and
+// export.GlobalRelayExporter without error and with its attachment
+// reachable in the archived output.
+func (s *EmailTestSuite) TestComplianceExportRoundTrip() {
+    timer := prometheus.NewTimer(testDuration.WithLabelValues("compliance_export_round_trip"))
+    defer timer.ObserveDuration()
+
+    thread := []models.Email{
+        {
+            MessageID:   "export-msg-1",
+            ThreadID:    "export-thread-1",
+            Subject:     "Quarterly Report",
+            Content:     "Please find the report attached.",
+            FromAddress: "alice@example.com",
+            ToAddresses: []string{"bob@example.com"},
+            SentAt:      time.Date(2026, 7, 20, 9, 0, 0, 0, time.UTC),
+            Attachments: []models.Attachment{
+                {AttachmentID: "export-att-1", Filename: "report.pdf", ContentType: "application/pdf", StoragePath: "blob://report.pdf"},
+            },
+        },
+        {
+            MessageID:   "export-msg-2",
+            ThreadID:    "export-thread-1",
+            Subject:     "Re: Quarterly Report",
+            Content:     "Thanks, reviewing now.",
+            FromAddress: "bob@example.com",
+            ToAddresses: []string{"alice@example.com"},
+            SentAt:      time.Date(2026, 7, 20, 10, 0, 0, 0, time.UTC),
+        },
+    }
+
+    attachmentStore := &fakeAttachmentStore{body: []byte("%PDF-1.4 fake report body")}
+
+    emlExporter, err := export.NewEMLExporter(attachmentStore, 0)
+    require.NoError(s.T(), err, "Failed to create EML exporter")
+    var emlBuf bytes.Buffer
+    require.NoError(s.T(), emlExporter.Write(s.ctx, thread, &emlBuf), "EML export failed")
+    emlZip, err := zip.NewReader(bytes.NewReader(emlBuf.Bytes()), int64(emlBuf.Len()))
+    require.NoError(s.T(), err, "Failed to read generated EML archive")
+    assert.True(s.T(), archiveContains(emlZip, "export-thread-1/export-msg-1/message.eml"))
+    assert.True(s.T(), archiveContains(emlZip, "export-thread-1/export-msg-1/report.pdf"))
+
+    csvExporter := export.NewCSVExporter(0)
+    var csvBuf bytes.Buffer
+    require.NoError(s.T(), csvExporter.Write(s.ctx, thread, &csvBuf), "CSV export failed")
+    assert.Contains(s.T(), csvBuf.String(), "export-msg-1")
+    assert.Contains(s.T(), csvBuf.String(), "report.pdf")
+
+    globalRelayExporter, err := export.NewGlobalRelayExporter(attachmentStore, 0)
+    require.NoError(s.T(), err, "Failed to create GlobalRelay exporter")
+    var grBuf bytes.Buffer
+    require.NoError(s.T(), globalRelayExporter.Write(s.ctx, thread, &grBuf), "GlobalRelay export failed")
+    grZip, err := zip.NewReader(bytes.NewReader(grBuf.Bytes()), int64(grBuf.Len()))
+    require.NoError(s.T(), err, "Failed to read generated GlobalRelay archive")
+    assert.True(s.T(), archiveContains(grZip, "export-thread-1/thread.html"))
+    assert.True(s.T(), archiveContains(grZip, "export-thread-1/attachments/report.pdf"))
+}
+
+// TestSMTPSenderDeliversRenderedTemplate renders the ConfirmEmail
+// transactional template and hands it to an smtp.SMTPSender pointed at an
+// in-process mock SMTP server, then asserts the server received the
+// expected subject, headers, and a multipart/alternative body with both a
+// plain-text and HTML part.
+func (s *EmailTestSuite) TestSMTPSenderDeliversRenderedTemplate() {
+    timer := prometheus.NewTimer(testDuration.WithLabelValues("smtp_sender_template_delivery"))
+    defer timer.ObserveDuration()
+
+    mock, err := newMockSMTPServer()
+    require.NoError(s.T(), err, "Failed to start mock SMTP server")
+    defer mock.Close()
+
+    renderer, err := templates.NewRenderer()
+    require.NoError(s.T(), err, "Failed to create template renderer")
+
+    email, err := renderer.Render(templates.ConfirmEmail, "en", map[string]string{
+        "RecipientName": "Dana",
+        "ActionURL":     "https://example.com/confirm?token=abc123",
+        "ExpiresIn":     "24 hours",
+    })
+    require.NoError(s.T(), err, "Failed to render confirm-email template")
+    email.FromAddress = "no-reply@example.com"
+    email.ToAddresses = []string{"dana@example.com"}
+    email.MessageID = "<confirm-test@example.com>"
+
+    sender := smtp.NewSMTPSender(smtp.SenderConfig{Host: mock.host, Port: mock.port})
+    require.NoError(s.T(), sender.Send(s.ctx, email), "Failed to send rendered template")
+
+    received := mock.awaitMessage(s.T())
+    assert.Contains(s.T(), received, "Subject: Confirm your email address")
+    assert.Contains(s.T(), received, "From: no-reply@example.com")
+    assert.Contains(s.T(), received, "To: dana@example.com")
+    assert.Contains(s.T(), received, "Message-ID: <confirm-test@example.com>")
+    assert.Contains(s.T(), received, "Content-Type: multipart/alternative")
+    assert.Contains(s.T(), received, "Content-Type: text/plain")
+    assert.Contains(s.T(), received, "Content-Type: text/html")
+    assert.Contains(s.T(), received, "Please confirm your email address")
+    assert.Contains(s.T(), received, "https://example.com/confirm?token=abc123")
+}
+
+// archiveContains reports whether zr has an entry named name.
+func archiveContains(zr *zip.Reader, name string) bool {
+    for _, f := range zr.File {
+        if f.Name == name {
+            return true
+        }
+    }
+    return false
+}
+
+// fakeAttachmentStore is an export.AttachmentStore fake that returns a
+// fixed body for every attachment, regardless of storage path.
+type fakeAttachmentStore struct {
+    body []byte
+}
+
+func (f *fakeAttachmentStore) FetchAttachment(ctx context.Context, storagePath string) ([]byte, error) {
+    return f.body, nil
+}
+
+// mockSMTPServer is a minimal plaintext SMTP server accepting a single
+// connection at a time, enough to exercise smtp.SMTPSender: it responds to
+// EHLO/MAIL/RCPT/DATA/QUIT and captures each message's DATA section.
+type mockSMTPServer struct {
+    listener net.Listener
+    host     string
+    port     int
+
+    messages chan string
+}
+
+// newMockSMTPServer starts a mockSMTPServer on an OS-assigned local port.
+func newMockSMTPServer() (*mockSMTPServer, error) {
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        return nil, fmt.Errorf("mock smtp server: failed to listen: %w", err)
+    }
+
+    addr := listener.Addr().(*net.TCPAddr)
+    server := &mockSMTPServer{
+        listener: listener,
+        host:     addr.IP.String(),
+        port:     addr.Port,
+        messages: make(chan string, 10),
+    }
+    go server.serve()
+    return server, nil
+}
+
+// serve accepts connections until the listener is closed.
+func (m *mockSMTPServer) serve() {
+    for {
+        conn, err := m.listener.Accept()
+        if err != nil {
+            return
+        }
+        go m.handleConn(conn)
+    }
+}
+
+// handleConn speaks just enough SMTP to accept one or more messages over a
+// single connection.
+func (m *mockSMTPServer) handleConn(conn net.Conn) {
+    defer conn.Close()
+
+    reader := bufio.NewReader(conn)
+    fmt.Fprintf(conn, "220 mock smtp server ready\r\n")
+
+    for {
+        line, err := reader.ReadString('\n')
+        if err != nil {
+            return
+        }
+        cmd := strings.ToUpper(strings.SplitN(strings.TrimSpace(line), " ", 2)[0])
+
+        switch cmd {
+        case "EHLO", "HELO":
+            fmt.Fprintf(conn, "250 mock smtp server\r\n")
+        case "MAIL", "RCPT":
+            fmt.Fprintf(conn, "250 OK\r\n")
+        case "NOOP":
+            fmt.Fprintf(conn, "250 OK\r\n")
+        case "DATA":
+            fmt.Fprintf(conn, "354 Start mail input; end with <CRLF>.<CRLF>\r\n")
+            var body strings.Builder
+            for {
+                dataLine, err := reader.ReadString('\n')
+                if err != nil {
+                    return
+                }
+                if strings.TrimRight(dataLine, "\r\n") == "." {
+                    break
+                }
+                body.WriteString(dataLine)
+            }
+            m.messages <- body.String()
+            fmt.Fprintf(conn, "250 OK\r\n")
+        case "QUIT":
+            fmt.Fprintf(conn, "221 Bye\r\n")
+            return
+        default:
+            fmt.Fprintf(conn, "250 OK\r\n")
+        }
+    }
+}
+
+// awaitMessage blocks until the server has captured a message, failing t
+// if none arrives in time.
+func (m *mockSMTPServer) awaitMessage(t *testing.T) string {
+    select {
+    case msg := <-m.messages:
+        return msg
+    case <-time.After(time.Second * 5):
+        t.Fatalf("mock smtp server: timed out waiting for a message")
+        return ""
+    }
+}
+
+// Close stops the mock server from accepting further connections.
+func (m *mockSMTPServer) Close() error {
+    return m.listener.Close()
+}
+
+// mockIMAPServer is a MailboxClient fake standing in for a real IMAP
+// server: it serves a fixed set of unseen messages by UID.
+type mockIMAPServer struct {
+    mu       sync.Mutex
+    messages map[uint32][]byte
+}
+
+func (m *mockIMAPServer) SelectMailbox(ctx context.Context, name string) error { return nil }
+
+func (m *mockIMAPServer) UnseenUIDs(ctx context.Context) ([]uint32, error) {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
+    uids := make([]uint32, 0, len(m.messages))
+    for uid := range m.messages {
+        uids = append(uids, uid)
+    }
+    return uids, nil
+}
+
+func (m *mockIMAPServer) FetchRFC822(ctx context.Context, uid uint32) ([]byte, error) {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
+    raw, ok := m.messages[uid]
+    if !ok {
+        return nil, fmt.Errorf("mock imap server: no message with uid %d", uid)
+    }
+    return raw, nil
+}
+
+func (m *mockIMAPServer) Close() error { return nil }
+
+// capturingProcessor is an incoming.EmailProcessor fake that records every
+// Email it's handed instead of persisting it.
+type capturingProcessor struct {
+    mu    sync.Mutex
+    items []*models.Email
+}
+
+func (p *capturingProcessor) ProcessEmail(ctx context.Context, email *models.Email) error {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    p.items = append(p.items, email)
+    return nil
+}
+
+func (p *capturingProcessor) received() []*models.Email {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    return append([]*models.Email(nil), p.items...)
+}
+
+// staticThreadResolver is an incoming.ThreadResolver fake that matches a
+// single configured In-Reply-To reference.
+type staticThreadResolver struct {
+    matchRef       string
+    threadID       string
+    conversationID string
+    nextPosition   int32
+}
+
+func (r *staticThreadResolver) ResolveThread(ctx context.Context, messageIDRefs []string, replyToken string) (string, string, int32, bool) {
+    for _, ref := range messageIDRefs {
+        if ref == r.matchRef {
+            return r.threadID, r.conversationID, r.nextPosition, true
+        }
+    }
+    return "", "", 0, false
+}
+
+// cannedReplyMessage is a fixed multipart/mixed RFC 822 message replying
+// to parent-message-id@example.com, carrying a text/plain body and a
+// single text attachment.
+const cannedReplyMessage = "From: recipient@test.com\r\n" +
+    "To: sender@test.com\r\n" +
+    "Subject: Re: Test Thread Parent\r\n" +
+    "Message-Id: <reply-message-id@example.com>\r\n" +
+    "In-Reply-To: <parent-message-id@example.com>\r\n" +
+    "Date: Mon, 27 Jul 2026 09:00:00 +0000\r\n" +
+    "Content-Type: multipart/mixed; boundary=\"BOUNDARY\"\r\n" +
+    "\r\n" +
+    "--BOUNDARY\r\n" +
+    "Content-Type: text/plain; charset=utf-8\r\n" +
+    "\r\n" +
+    "Reply body content\r\n" +
+    "--BOUNDARY\r\n" +
+    "Content-Type: text/plain\r\n" +
+    "Content-Disposition: attachment; filename=\"note.txt\"\r\n" +
+    "\r\n" +
+    "attachment contents\r\n" +
+    "--BOUNDARY--\r\n"
+
 // setupTestEnvironment initializes the test environment
 func setupTestEnvironment() error {
     // Environment setup logic
@@ -18,6 +18,7 @@ import (
     "github.com/stretchr/testify/mock" // v1.8.4
     "github.com/stretchr/testify/require" // v1.8.4
 
+    "github.com/email-management-platform/backend/email-service/internal/config"
     "github.com/email-management-platform/backend/email-service/internal/handlers"
     "github.com/email-management-platform/backend/email-service/internal/models"
     "github.com/email-management-platform/backend/email-service/internal/services"
@@ -74,13 +75,28 @@ func (m *MockEmailService) ListEmails(ctx context.Context, opts *services.ListEm
     return args.Get(0).(*services.ListEmailsResponse), args.Error(1)
 }
 
+// testRateLimitConfig returns a permissive RateLimitConfig suitable for
+// tests that aren't exercising rate limiting behavior directly.
+func testRateLimitConfig() config.RateLimitConfig {
+    return config.RateLimitConfig{
+        ReadRatePerSecond:   1000,
+        ReadBurst:           1000,
+        SendRatePerSecond:   1000,
+        SendBurst:           1000,
+        GlobalRatePerSecond: 10000,
+        GlobalBurst:         10000,
+        VisitorTTL:          time.Minute,
+        CleanupInterval:     time.Minute,
+    }
+}
+
 // setupTestRouter creates a test router with the email handler
 func setupTestRouter(mockService *MockEmailService) (*gin.Engine, *handlers.EmailHandler) {
     gin.SetMode(gin.TestMode)
     router := gin.New()
     router.Use(gin.Recovery())
 
-    handler, err := handlers.NewEmailHandler(mockService)
+    handler, err := handlers.NewEmailHandler(mockService, testRateLimitConfig(), nil)
     if err != nil {
         panic(fmt.Sprintf("failed to create handler: %v", err))
     }
@@ -117,7 +133,7 @@ func TestNewEmailHandler(t *testing.T) {
         t.Run(tt.name, func(t *testing.T) {
             t.Parallel()
 
-            handler, err := handlers.NewEmailHandler(tt.service)
+            handler, err := handlers.NewEmailHandler(tt.service, testRateLimitConfig(), nil)
             if tt.expectError {
                 assert.Error(t, err)
                 assert.Nil(t, handler)